@@ -1,5 +1,17 @@
 module poke
 
-go 1.20
+go 1.23.1
 
-require github.com/gorilla/websocket v1.5.3
+require (
+	github.com/gorilla/websocket v1.5.3
+	github.com/thanhduy1706/PokeDBC v0.0.0
+)
+
+require (
+	go.etcd.io/bbolt v1.4.3 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/thanhduy1706/PokeDBC => ../
@@ -1,35 +1,40 @@
 package main
 
 import (
-	"encoding/json"
+	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
 	"sync"
 	"time"
 	"github.com/gorilla/websocket"
+	"github.com/thanhduy1706/PokeDBC/assets"
+	"github.com/thanhduy1706/PokeDBC/locale"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+	"github.com/thanhduy1706/PokeDBC/store"
 )
 
 // Cell represents each cell on the map, potentially containing a Pokémon
 type Cell struct {
-	Pokemon *Pokemon
+	Pokemon *pokemon.Pokemon
 }
 
-// Pokemon represents a Pokémon with its attributes
-type Pokemon struct {
-	Name      string    `json:"name"`
-	Level     int       `json:"level"`
-	EV        float64   `json:"ev"`
-	SpawnedAt time.Time
+// cellView is what front.html gets of a Cell: the key it looks the
+// artwork up by, not the whole Pokémon.
+type cellView struct {
+	Pokemon *pokemonView
+}
+
+type pokemonView struct {
+	Key string `json:"key"`
 }
 
 // Player represents a player in the game
 type Player struct {
 	ID       int
 	X, Y     int
-	Captured []*Pokemon
+	Captured []*pokemon.Pokemon
 }
 
 const (
@@ -37,6 +42,7 @@ const (
 	PokemonPerWave   = 50
 	PokemonLifetime  = 5 * time.Minute
 	MaxPokemons      = 200
+	AssetsDir        = "../Pokedex/assets" // where Pokedex -assets run in Pokedex/ downloads the artwork
 )
 
 var (
@@ -47,27 +53,48 @@ var (
 		CheckOrigin: func(r *http.Request) bool { return true },
 	}
 	globalMutex sync.Mutex
-	pokemonData []Pokemon
+	pokemonData []pokemon.Pokemon
 )
 
-// loadPokemonData loads Pokémon data from a JSON file
-func loadPokemonData() {
-	data, err := ioutil.ReadFile("pokemon.json")
-	if err != nil {
-		log.Fatalf("Failed to read pokemon.json: %v", err)
+// loadPokemonData loads the species to spawn: every entry of the store
+// database at db (see Pokedex -db) if it is set, else of the JSON pokedex
+// at dex. The Pokedex scraper's output comes first: the bundled
+// pokemon.json predates it and has no abilities, so Pokémon spawned from
+// it are caught without one.
+func loadPokemonData(db, dex string) {
+	var err error
+	if db != "" {
+		pokemonData, err = loadStore(db)
+		if err != nil {
+			log.Fatalf("Failed to load %s: %v", db, err)
+		}
+		fmt.Printf("Loaded %d Pokémon from %s\n", len(pokemonData), db)
+		return
 	}
 
-	if err := json.Unmarshal(data, &pokemonData); err != nil {
-		log.Fatalf("Failed to parse pokemon.json: %v", err)
+	pokemonData, err = pokemon.Load(dex)
+	if err != nil {
+		fmt.Printf("Can't load %s, falling back to pokemon.json, which has no abilities: %v\n", dex, err)
+		pokemonData, err = pokemon.Load("pokemon.json")
 	}
-
-	if len(pokemonData) > 50 {
-		pokemonData = pokemonData[:50]
+	if err != nil {
+		log.Fatalf("Failed to load pokemon.json: %v", err)
 	}
 
 	fmt.Printf("Loaded %d Pokémon from json\n", len(pokemonData))
 }
 
+// loadStore reads every entry of a store database, opened read-only so
+// Pokedex serve and the other world server can use it at the same time.
+func loadStore(path string) ([]pokemon.Pokemon, error) {
+	db, err := store.OpenReadOnly(path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return db.All()
+}
+
 // spawnPokemon spawns new Pokémon on the map
 func spawnPokemon() {
 	lock.Lock()
@@ -79,17 +106,12 @@ func spawnPokemon() {
 			return
 		}
 
-		pokemon := pokemonData[rand.Intn(len(pokemonData))]
-		level := rand.Intn(100) + 1
-		ev := 0.5 + rand.Float64()*(1.0-0.5)
+		species := pokemonData[rand.Intn(len(pokemonData))]
+		level := rand.Intn(pokemon.MaxLevel) + 1
 		x, y := rand.Intn(MapSize), rand.Intn(MapSize)
 
-		world[x][y].Pokemon = &Pokemon{
-			Name:      pokemon.Name,
-			Level:     level,
-			EV:        ev,
-			SpawnedAt: time.Now(),
-		}
+		wild := pokemon.Spawn(species, level, nil)
+		world[x][y].Pokemon = &wild
 	}
 }
 
@@ -142,7 +164,7 @@ func capturePokemon(p *Player) {
 		cell.Pokemon = nil
 
 		fmt.Printf("Player %d captured a Pokémon: %v at position X = %d, Y = %d\n", p.ID, pokemon.Name, p.X, p.Y)
-		fmt.Printf("Captured Pokémon Details:\n- Name: %s\n- Level: %d\n- EV: %.2f\n", pokemon.Name, pokemon.Level, pokemon.EV)
+		fmt.Printf("Captured Pokémon Details:\n- Name: %s\n- Level: %d\n- Nature: %s\n- Ability: %s\n", pokemon.Name, pokemon.Level, pokemon.Nature, pokemon.Ability)
 
 		cell = &world[p.X][p.Y]
 	}
@@ -166,7 +188,9 @@ func wsHandler(w http.ResponseWriter, r *http.Request) {
 
 	p, err := addPlayer()
 	if err != nil {
-		conn.WriteJSON(map[string]string{"error": "Only one player allowed"})
+		// front.html may ask for a language with ?lang=vi
+		lang := locale.Parse(r.URL.Query().Get("lang") + "," + r.Header.Get("Accept-Language"))
+		conn.WriteJSON(map[string]string{"error": locale.Sprintf(lang, "player.limit")})
 		fmt.Println(err)
 		conn.Close()
 		return
@@ -184,12 +208,28 @@ func wsHandler(w http.ResponseWriter, r *http.Request) {
 	gameLoop(conn, p)
 }
 
+// worldView is the world as sent to front.html.
+func worldView() [MapSize][MapSize]cellView {
+	lock.Lock()
+	defer lock.Unlock()
+
+	var view [MapSize][MapSize]cellView
+	for x := range world {
+		for y, cell := range world[x] {
+			if cell.Pokemon != nil {
+				view[x][y].Pokemon = &pokemonView{Key: cell.Pokemon.Key}
+			}
+		}
+	}
+	return view
+}
+
 // gameLoop processes the game loop for a player
 func gameLoop(conn *websocket.Conn, p *Player) {
 	for {
 		data := map[string]interface{}{
 			"player": map[string]int{"X": p.X, "Y": p.Y},
-			"world":  world,
+			"world":  worldView(),
 		}
 		if err := conn.WriteJSON(data); err != nil {
 			fmt.Println("Error writing to websocket:", err)
@@ -228,8 +268,13 @@ func gameLoop(conn *websocket.Conn, p *Player) {
 
 // main initializes the server and game logic
 func main() {
+	db := flag.String("db", "", "spawn the species in this store database, e.g. ../Pokedex/pokedex.db")
+	dex := flag.String("pokedex", "../Pokedex/pokedex.json", "without -db, spawn the species in this JSON pokedex")
+	assetsDir := flag.String("assets", AssetsDir, "serve the artwork Pokedex -assets downloaded into this directory")
+	flag.Parse()
+
 	rand.Seed(time.Now().UnixNano())
-	loadPokemonData()
+	loadPokemonData(*db, *dex)
 
 	go func() {
 		for {
@@ -246,6 +291,7 @@ func main() {
 	}()
 
 	http.HandleFunc("/ws", wsHandler)
+	http.Handle("/assets/", http.StripPrefix("/assets/", assets.Handler(*assetsDir)))
 	fmt.Println("Server started at :8080")
 	http.ListenAndServe(":8080", nil)
 }
@@ -2,13 +2,20 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"math/rand"
 	"net"
+	"net/http"
 	"os"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/thanhduy1706/PokeDBC/assets"
+	"github.com/thanhduy1706/PokeDBC/locale"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+	"github.com/thanhduy1706/PokeDBC/store"
 )
 
 const (
@@ -16,53 +23,64 @@ const (
 	DefaultMaxPokemons = 200
 	DefaultSpawnRate   = 50
 	DefaultDespawnTime = 5 * time.Minute
+	AssetsAddr         = ":8001"
+	DefaultAssetsDir   = "Pokedex/assets" // where Pokedex -assets run in Pokedex/ downloads the artwork
 )
 
+// Species are the names of the Pokémon the world spawns.
+var Species = []string{"Pikachu", "Charmander", "Bulbasaur", "Squirtle"}
+
 type Coordinate struct {
 	x, y int
 }
 
-type Pokemon struct {
-	Name      string
-	Level     int
-	EV        float64
-	SpawnedAt time.Time
-}
-
 type Player struct {
 	ID       string
 	Position Coordinate
-	Pokemons []Pokemon
+	Pokemons []pokemon.Pokemon
+	Lang     string // set with the LANG command, see package locale
 	Mutex    sync.Mutex
 }
 
+// send writes the catalog message id to the player in their language.
+func (player *Player) send(conn net.Conn, id string, args ...interface{}) {
+	conn.Write([]byte(locale.Sprintf(player.Lang, id, args...) + "\n"))
+}
+
 type GameServer struct {
-	World       map[Coordinate]*Pokemon
+	World       map[Coordinate]*pokemon.Pokemon
 	Players     map[string]*Player
 	Mutex       sync.Mutex
-	Pokedex     []string
+	Pokedex     []pokemon.Pokemon // the species spawned, see LoadSpecies
 	NewPlayers  chan net.Conn
 	WorldSize   int
 	MaxPokemons int
 	SpawnRate   int
 	DespawnTime time.Duration
+	AssetsDir   string // the artwork served on AssetsAddr
 }
 
 func NewGameServer() *GameServer {
+	pokedex := make([]pokemon.Pokemon, len(Species))
+	for i, name := range Species {
+		pokedex[i] = pokemon.Pokemon{Name: name}
+	}
 	return &GameServer{
-		World:       make(map[Coordinate]*Pokemon),
+		World:       make(map[Coordinate]*pokemon.Pokemon),
 		Players:     make(map[string]*Player),
-		Pokedex:     []string{"Pikachu", "Charmander", "Bulbasaur", "Squirtle"},
+		Pokedex:     pokedex,
 		NewPlayers:  make(chan net.Conn),
 		WorldSize:   DefaultWorldSize,
 		MaxPokemons: DefaultMaxPokemons,
 		SpawnRate:   DefaultSpawnRate,
 		DespawnTime: DefaultDespawnTime,
+		AssetsDir:   DefaultAssetsDir,
 	}
 }
 
 func (server *GameServer) Start() {
 	go server.acceptConnections()
+	go server.serveAssets()
 	go server.spawnPokemon()
 	server.gameLoop()
 }
@@ -81,10 +99,21 @@ func (server *GameServer) acceptConnections() {
 	}
 }
 
+// serveAssets serves the artwork and its manifest.json over HTTP, for
+// clients that draw the world.
+func (server *GameServer) serveAssets() {
+	http.Handle("/assets/", http.StripPrefix("/assets/", assets.Handler(server.AssetsDir)))
+	fmt.Println("Serving assets on port: " + AssetsAddr)
+	if err := http.ListenAndServe(AssetsAddr, nil); err != nil {
+		fmt.Printf("Error serving assets: %v\n", err)
+	}
+}
+
 func (server *GameServer) addPlayer(conn net.Conn) {
 	id := fmt.Sprintf("player_%d", time.Now().UnixNano())
 	player := &Player{ // Create a new player
-		ID: id,
+		ID:   id,
+		Lang: locale.Default,
 		Position: Coordinate{
 			x: rand.Intn(server.WorldSize),
 			y: rand.Intn(server.WorldSize),
@@ -111,6 +140,10 @@ func (server *GameServer) handlePlayer(conn net.Conn, player *Player) {
 		}
 		command := string(buffer[:n])
 		command = strings.TrimSpace(command)
+		if lang, ok := strings.CutPrefix(command, "LANG "); ok {
+			server.setLang(player, conn, lang)
+			continue
+		}
 		switch command {
 		case "UP":
 			player.Move(0, -1, server.WorldSize)
@@ -123,7 +156,7 @@ func (server *GameServer) handlePlayer(conn net.Conn, player *Player) {
 		case "INVENTORY":
 			server.showInventory(player, conn)
 		default:
-			conn.Write([]byte("Invalid command\n"))
+			player.send(conn, "command.invalid")
 			continue
 		}
 		server.checkForPokemon(player, conn) // Check for Pokemon in the player's position
@@ -133,16 +166,29 @@ func (server *GameServer) handlePlayer(conn net.Conn, player *Player) {
 	server.Mutex.Unlock()
 }
 
+// setLang switches the language of the player's messages, e.g. LANG vi.
+func (server *GameServer) setLang(player *Player, conn net.Conn, tag string) {
+	lang, ok := locale.Lookup(tag)
+	if !ok {
+		player.send(conn, "lang.unknown", tag, strings.Join(locale.Supported, ", "))
+		return
+	}
+	player.Mutex.Lock()
+	player.Lang = lang
+	player.Mutex.Unlock()
+	player.send(conn, "lang.set")
+}
+
 func (server *GameServer) showInventory(player *Player, conn net.Conn) {
 	player.Mutex.Lock() // Lock the player mutex
 	defer player.Mutex.Unlock()
 	if len(player.Pokemons) == 0 { // Check if the player has any Pokemon
-		conn.Write([]byte("Your inventory is empty.\n"))
+		player.send(conn, "inventory.empty")
 		return
 	}
-	inventory := "Your Pokemon inventory:\n"
+	inventory := locale.Sprintf(player.Lang, "inventory") + "\n"
 	for i, pokemon := range player.Pokemons {
-		inventory += fmt.Sprintf("%d: %s (Level %d, EV %.2f)\n", i, pokemon.Name, pokemon.Level, pokemon.EV)
+		inventory += locale.Sprintf(player.Lang, "inventory.item", i, pokemon.LocalName(player.Lang), pokemon.Level, pokemon.Nature) + "\n"
 	}
 	conn.Write([]byte(inventory)) // Send the inventory message to the player
 }
@@ -164,14 +210,14 @@ func (server *GameServer) checkForPokemon(player *Player, conn net.Conn) {
 		player.Mutex.Lock()
 		if len(player.Pokemons) < server.MaxPokemons { // Check if the player's inventory is full
 			player.Pokemons = append(player.Pokemons, *pokemon)
-			conn.Write([]byte(fmt.Sprintf("You captured a %s at position (%d, %d)!\n", pokemon.Name, player.Position.x, player.Position.y)))
+			player.send(conn, "capture.ok", pokemon.LocalName(player.Lang), player.Position.x, player.Position.y)
 		} else {
-			conn.Write([]byte(fmt.Sprintf("Your Pokemon inventory is full at position (%d, %d)!\n", player.Position.x, player.Position.y)))
+			player.send(conn, "capture.full", player.Position.x, player.Position.y)
 		}
 		player.Mutex.Unlock()
 		delete(server.World, player.Position) // Remove the Pokemon from the world
 	} else {
-		conn.Write([]byte(fmt.Sprintf("No Pokemon here at position (%d, %d).\n", player.Position.x, player.Position.y)))
+		player.send(conn, "capture.none", player.Position.x, player.Position.y)
 	}
 }
 
@@ -184,14 +230,10 @@ func (server *GameServer) spawnPokemon() {
 				x: rand.Intn(server.WorldSize),
 				y: rand.Intn(server.WorldSize),
 			}
-			pokemon := &Pokemon{
-				Name:      server.Pokedex[rand.Intn(len(server.Pokedex))],
-				Level:     rand.Intn(100) + 1,
-				EV:        0.5 + rand.Float64()*0.5,
-				SpawnedAt: time.Now(), // Set the spawn time to the current time
-			}
-			server.World[coord] = pokemon // Add the Pokemon to the world
-			fmt.Printf("Spawned %s at position: (%d, %d)\n", pokemon.Name, coord.x, coord.y)
+			species := server.Pokedex[rand.Intn(len(server.Pokedex))]
+			wild := pokemon.Spawn(species, rand.Intn(pokemon.MaxLevel)+1, nil) // Rolls IVs and nature, spawned now
+			server.World[coord] = &wild                                        // Add the Pokemon to the world
+			fmt.Printf("Spawned %s at position: (%d, %d)\n", wild.Name, coord.x, coord.y)
 		}
 		server.Mutex.Unlock()
 	}
@@ -213,6 +255,53 @@ func (server *GameServer) gameLoop() {
 	}
 }
 
+// LoadSpecies looks the names up in the store database at path (see
+// Pokedex -db), so the world's Pokémon get their species' base stats,
+// growth rate and abilities. Names the store doesn't have keep only their
+// name.
+func (server *GameServer) LoadSpecies(path string, names []string) error {
+	db, err := store.OpenReadOnly(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return server.setSpecies(path, names, db.Find)
+}
+
+// LoadSpeciesFile is LoadSpecies for a JSON pokedex written by the Pokedex
+// scraper, such as Pokedex/pokedex.json.
+func (server *GameServer) LoadSpeciesFile(path string, names []string) error {
+	entries, err := pokemon.Load(path)
+	if err != nil {
+		return err
+	}
+	return server.setSpecies(path, names, func(q store.Query) ([]pokemon.Pokemon, error) {
+		for _, p := range entries {
+			if q.Match(p) {
+				return []pokemon.Pokemon{p}, nil
+			}
+		}
+		return nil, nil
+	})
+}
+
+func (server *GameServer) setSpecies(from string, names []string, find func(store.Query) ([]pokemon.Pokemon, error)) error {
+	pokedex := make([]pokemon.Pokemon, len(names))
+	for i, name := range names {
+		found, err := find(store.Query{Name: name, Limit: 1})
+		if err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			fmt.Printf("%s is not in %s, spawning it without species data\n", name, from)
+			found = []pokemon.Pokemon{{Name: name}}
+		}
+		pokedex[i] = found[0]
+	}
+	server.Pokedex = pokedex
+	return nil
+}
+
 func (server *GameServer) savePlayerPokemons(player *Player) {
 	player.Mutex.Lock()
 	defer player.Mutex.Unlock()
@@ -230,8 +319,24 @@ func (server *GameServer) savePlayerPokemons(player *Player) {
 }
 
 func main() {
+	db := flag.String("db", "", "look the spawned species up in this store database, e.g. pokedex.db")
+	dex := flag.String("pokedex", "Pokedex/pokedex.json", "without -db, look the spawned species up in this JSON pokedex")
+	assetsDir := flag.String("assets", DefaultAssetsDir, "serve the artwork Pokedex -assets downloaded into this directory")
+	flag.Parse()
+
 	rand.Seed(time.Now().UnixNano()) // Seed the random number generator
 	server := NewGameServer()
+	server.AssetsDir = *assetsDir
+	if *db != "" {
+		if err := server.LoadSpecies(*db, Species); err != nil {
+			fmt.Printf("Error loading species from %s: %v\n", *db, err)
+			return
+		}
+	} else if err := server.LoadSpeciesFile(*dex, Species); err != nil {
+		// Spawning still works from the names alone, but without base
+		// stats or abilities there is no ability to roll for a catch.
+		fmt.Printf("Error loading species from %s, spawning Pokémon without abilities: %v\n", *dex, err)
+	}
 	go func() {
 		for conn := range server.NewPlayers { // Add new players to the server
 			server.addPlayer(conn)
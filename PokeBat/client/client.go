@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/thanhduy1706/PokeDBC/PokeBat/protocol"
+	"github.com/thanhduy1706/PokeDBC/locale"
+)
+
+// readLine reads a line the player typed, without the newline.
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// printMoves lists a Pokémon's moves by the index to pick them with.
+func printMoves(moves []protocol.Move) {
+	for i, m := range moves {
+		priority := ""
+		if m.Priority != 0 {
+			priority = fmt.Sprintf(", priority %+d", m.Priority)
+		}
+		fmt.Printf("  %d: %s (%s, %s, power %d, accuracy %d, PP %d/%d%s)\n", i, m.Name, m.Type, m.Category, m.Power, m.Accuracy, m.PP, m.MaxPP, priority)
+	}
+}
+
+// chooseAction prompts until the player types a valid action and sends it:
+// the index of one of moves, switch or surrender. A Pokémon without moves
+// or PP attacks anyway, with "attack".
+func chooseAction(reader *bufio.Reader, conn *protocol.Conn, moves []protocol.Move) error {
+	usable := false
+	for _, m := range moves {
+		usable = usable || m.PP > 0
+	}
+	for {
+		if usable {
+			printMoves(moves)
+			fmt.Printf("Choose an action: [0-%d/switch/surrender]\n", len(moves)-1)
+		} else {
+			fmt.Println("Choose an action: [attack/switch/surrender]")
+		}
+		action, err := readLine(reader)
+		if err != nil {
+			return err
+		}
+
+		// Validate the action and send it only if it's valid.
+		switch action {
+		case protocol.ActionSwitch, protocol.ActionSurrender:
+			return conn.Send(protocol.TypeAction, protocol.Action{Action: action}, "")
+		case protocol.ActionAttack:
+			if !usable {
+				return conn.Send(protocol.TypeAction, protocol.Action{Action: action}, "")
+			}
+		}
+		if i, err := strconv.Atoi(action); err == nil && usable && i >= 0 && i < len(moves) {
+			return conn.Send(protocol.TypeAction, protocol.Action{Action: protocol.ActionAttack, Move: i}, "")
+		}
+		fmt.Println("Invalid action. Try again.")
+	}
+}
+
+// chooseRoom prompts for the room to play in and sends it: a room to join,
+// "+name" to create one, or nothing to play whoever comes next.
+func chooseRoom(reader *bufio.Reader, conn *protocol.Conn) error {
+	fmt.Print("Enter a room to join, +name to create one, or nothing to play anyone: ")
+	room, err := readLine(reader)
+	if err != nil {
+		return err
+	}
+	join := protocol.Join{Room: room}
+	if strings.HasPrefix(room, "+") {
+		join = protocol.Join{Room: strings.TrimSpace(room[1:]), Create: true}
+	}
+	return conn.Send(protocol.TypeJoin, join, "")
+}
+
+// choosePokemon prompts for the team's next member and sends the choice.
+func choosePokemon(reader *bufio.Reader, conn *protocol.Conn, slot, last int) error {
+	for {
+		fmt.Printf("Choose your Pokémon #%d (0-%d): ", slot, last)
+		line, err := readLine(reader)
+		if err != nil {
+			return err
+		}
+		choice, err := strconv.Atoi(line)
+		if err == nil && choice >= 0 && choice <= last {
+			return conn.Send(protocol.TypeChoose, protocol.Choose{Index: choice}, "")
+		}
+		fmt.Printf("Invalid choice. Please select a Pokémon between 0 and %d.\n", last)
+	}
+}
+
+func main() {
+	lang := flag.String("lang", locale.Parse(os.Getenv("LANG")), "language of the server's messages: "+strings.Join(locale.Supported, " or "))
+	flag.Parse()
+
+	// Connect to the server at localhost:8080.
+	c, err := net.Dial("tcp", "localhost:8080")
+	if err != nil {
+		fmt.Println("Error connecting to server:", err)
+		return
+	}
+	conn := protocol.NewConn(c)
+	defer conn.Close() // Ensure connection is closed when the program exits.
+
+	// Create a buffered reader for user input.
+	reader := bufio.NewReader(os.Stdin) // Đọc đầu vào từ người dùng qua bàn phím.
+
+	// Prompt the player to enter their name.
+	fmt.Print("Enter your player name: ")
+	playerName, _ := readLine(reader)
+
+	// Validate that the player name is not empty.
+	if playerName == "" {
+		fmt.Println("Player name cannot be empty. Exiting...")
+		return
+	}
+
+	// Send the player's name to the server.
+	if err := conn.Send(protocol.TypeHello, protocol.Hello{Name: playerName, Lang: *lang}, ""); err != nil {
+		fmt.Println("Error sending player name:", err)
+		return
+	}
+
+	// Answer the server's messages until the game is over: pick a room, then
+	// the team, then an action each turn.
+	var welcome protocol.Welcome
+	var moves []protocol.Move // of the active Pokémon, as of the last turn
+	picked := 0               // team members chosen so far
+	for {
+		e, err := conn.Receive()
+		if err != nil {
+			fmt.Println("Error receiving server message:", err)
+			return
+		}
+		if e.Text != "" {
+			fmt.Println(e.Text)
+		}
+
+		switch e.Type {
+		case protocol.TypeLobby:
+			var lobby protocol.Lobby
+			if err = e.Decode(&lobby); err == nil {
+				if len(lobby.Rooms) > 0 {
+					fmt.Println("Rooms:", strings.Join(lobby.Rooms, ", "))
+				}
+				err = chooseRoom(reader, conn)
+			}
+
+		case protocol.TypeWelcome:
+			if err = e.Decode(&welcome); err == nil {
+				for i, p := range welcome.Choices {
+					fmt.Printf("%d: %s (%s, Level %d, HP %d)\n", i, p.Name, strings.Join(p.Types, "/"), p.Level, p.MaxHP)
+					printMoves(p.Moves)
+				}
+				err = choosePokemon(reader, conn, 1, len(welcome.Choices)-1)
+			}
+
+		case protocol.TypeChosen:
+			var chosen protocol.Chosen
+			if err = e.Decode(&chosen); err == nil {
+				picked = chosen.Slot
+				if picked < welcome.TeamSize {
+					err = choosePokemon(reader, conn, picked+1, len(welcome.Choices)-1)
+				}
+			}
+
+		case protocol.TypeTurnStart:
+			// Both players choose an action every turn.
+			var turn protocol.TurnStart
+			if err = e.Decode(&turn); err == nil {
+				moves = turn.Moves
+				if err = chooseAction(reader, conn, moves); err == nil {
+					fmt.Println("Waiting for your opponent...")
+				}
+			}
+
+		case protocol.TypeError:
+			// The server turned the last answer down; answer again.
+			var perr protocol.Error
+			if err = e.Decode(&perr); err != nil {
+				break
+			}
+			switch perr.Code {
+			case protocol.ErrRoomTaken, protocol.ErrNoRoom:
+				err = chooseRoom(reader, conn)
+			case protocol.ErrInvalidChoice:
+				err = choosePokemon(reader, conn, picked+1, len(welcome.Choices)-1)
+			case protocol.ErrInvalidAction:
+				if err = chooseAction(reader, conn, moves); err == nil {
+					fmt.Println("Waiting for your opponent...")
+				}
+			case protocol.ErrVersion:
+				return
+			}
+
+		case protocol.TypeGameOver:
+			fmt.Println("Game has ended. Thank you for playing!")
+			return
+		}
+		if err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,352 @@
+// Package protocol is what PokeBat's server and client say to each other.
+// Every message is an Envelope, one JSON object per line, tagged with its
+// Type and the protocol Version; the payload's shape depends on the type.
+//
+// A game goes:
+//
+//	client -> Hello             name and language
+//	server -> Lobby             the rooms waiting for a player
+//	client -> Join              the queue, or a room to create or join
+//	server -> Waiting or Error  until an opponent turns up
+//	server -> Assign            your player number and opponent
+//	server -> Welcome           the Pokémon to pick from
+//	client -> Choose            one pick, repeated until the team is full
+//	server -> Chosen or Error   for each pick
+//	server -> BattleStart       both active Pokémon
+//	server -> TurnStart         to both players at once
+//	client -> Action            from both, then the server plays them
+//	server -> Damage, Faint, Switch, Experience, GameOver, Error
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Version is bumped on changes old peers can't read; 1 was the free-text
+// protocol this package replaced. A peer receiving another version
+// answers with an Error and hangs up.
+const Version = 2
+
+// Type tells what an Envelope's payload is.
+type Type string
+
+const (
+	TypeHello       Type = "hello"        // client: Hello
+	TypeLobby       Type = "lobby"        // server: Lobby
+	TypeJoin        Type = "join"         // client: Join
+	TypeWaiting     Type = "waiting"      // server: Waiting
+	TypeAssign      Type = "assign"       // server: Assign
+	TypeWelcome     Type = "welcome"      // server: Welcome
+	TypeChoose      Type = "choose"       // client: Choose
+	TypeChosen      Type = "chosen"       // server: Chosen
+	TypeBattleStart Type = "battle_start" // server: BattleStart
+	TypeTurnStart   Type = "turn_start"   // server: TurnStart
+	TypeAction      Type = "action"       // client: Action
+	TypeDamage      Type = "damage"       // server: Damage
+	TypeFaint       Type = "faint"        // server: Faint
+	TypeSwitch      Type = "switch"       // server: Switch
+	TypeExperience  Type = "experience"   // server: Experience
+	TypeGameOver    Type = "game_over"    // server: GameOver
+	TypeError       Type = "error"        // either: Error
+)
+
+// Envelope wraps every message. Text is the message for people, in the
+// language the client asked for; programs go by Type and Payload.
+type Envelope struct {
+	Type    Type            `json:"type"`
+	Version int             `json:"version"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Text    string          `json:"text,omitempty"`
+}
+
+// Decode unmarshals the payload into v.
+func (e Envelope) Decode(v interface{}) error {
+	if len(e.Payload) == 0 {
+		return fmt.Errorf("%s message without payload", e.Type)
+	}
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("decoding %s payload: %w", e.Type, err)
+	}
+	return nil
+}
+
+// Pokemon is a team member as the players see it. Moves are only told to
+// the Pokémon's owner.
+type Pokemon struct {
+	Key   string   `json:"key"`
+	Name  string   `json:"name"` // in the receiver's language
+	Types []string `json:"types,omitempty"`
+	Level int      `json:"level"`
+	HP    int      `json:"hp"`
+	MaxHP int      `json:"max_hp"`
+	Moves []Move   `json:"moves,omitempty"`
+}
+
+// Move is one of a Pokémon's moves, with the PP it has left. Category is
+// "physical", "special" or "status"; Accuracy is 0 for moves that never
+// miss.
+type Move struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Category string `json:"category"`
+	Power    int    `json:"power"`
+	Accuracy int    `json:"accuracy"`
+	PP       int    `json:"pp"`
+	MaxPP    int    `json:"max_pp"`
+	Priority int    `json:"priority,omitempty"`
+}
+
+// Hello introduces the player. Lang is a language tag for Text, e.g. "vi".
+type Hello struct {
+	Name string `json:"name"`
+	Lang string `json:"lang,omitempty"`
+}
+
+// Lobby lists the rooms with a player waiting for an opponent.
+type Lobby struct {
+	Rooms []string `json:"rooms"`
+}
+
+// Join asks for an opponent: anyone in the queue if Room is empty, else
+// whoever waits in Room. Create makes a new room instead, failing with
+// ErrRoomTaken if it exists; joining a room that doesn't fails with
+// ErrNoRoom.
+type Join struct {
+	Room   string `json:"room,omitempty"`
+	Create bool   `json:"create,omitempty"`
+}
+
+// Waiting tells a player they wait in the queue, or in Room, for an
+// opponent.
+type Waiting struct {
+	Room string `json:"room,omitempty"`
+}
+
+// Assign starts a game, giving a client its player number, 1 or 2, and
+// its opponent's name.
+type Assign struct {
+	Player   int    `json:"player"`
+	Opponent string `json:"opponent"`
+}
+
+// Welcome lists the Pokémon to choose TeamSize of.
+type Welcome struct {
+	Name     string    `json:"name"`
+	Choices  []Pokemon `json:"choices"`
+	TeamSize int       `json:"team_size"`
+}
+
+// Choose picks Choices[Index] of the Welcome.
+type Choose struct {
+	Index int `json:"index"`
+}
+
+// Chosen confirms a pick as team member Slot, counting from 1.
+type Chosen struct {
+	Slot    int     `json:"slot"`
+	Pokemon Pokemon `json:"pokemon"`
+}
+
+// BattleStart announces both players' first Pokémon.
+type BattleStart struct {
+	Yours    Pokemon `json:"yours"`
+	Opponent Pokemon `json:"opponent"`
+}
+
+// TurnStart asks a player for their action this turn, giving the moves of
+// their active Pokémon. Both players are asked at once; once both have
+// answered, switches go first, then moves by Priority and then by the
+// Speed of the Pokémon using them.
+type TurnStart struct {
+	Turn  int    `json:"turn"`
+	Moves []Move `json:"moves,omitempty"`
+}
+
+// Actions a player can take on their turn.
+const (
+	ActionAttack    = "attack"
+	ActionSwitch    = "switch"
+	ActionSurrender = "surrender"
+)
+
+// Action is what a player does this turn. An attack uses the active
+// Pokémon's Moves[Move]; one that has no PP left in any move struggles
+// whatever Move says.
+type Action struct {
+	Action string `json:"action"`
+	Move   int    `json:"move,omitempty"`
+}
+
+// Damage reports an attack to both players, with the defender's HP after
+// it. Effectiveness is the type matchup's multiplier: 2 or 4 for super
+// effective moves, 0.5 or 0.25 for not very effective ones, 0 for moves
+// the defender is immune to.
+type Damage struct {
+	Attacker      int     `json:"attacker"` // player number
+	From          Pokemon `json:"from"`
+	To            Pokemon `json:"to"`
+	Move          Move    `json:"move"`
+	Damage        int     `json:"damage"`
+	Missed        bool    `json:"missed,omitempty"`
+	Effectiveness float64 `json:"effectiveness"`
+	Critical      bool    `json:"critical,omitempty"`
+}
+
+// Faint reports a player's Pokémon fainting.
+type Faint struct {
+	Player  int     `json:"player"`
+	Pokemon Pokemon `json:"pokemon"`
+}
+
+// Switch reports a player's new active Pokémon, chosen or after a faint.
+type Switch struct {
+	Player  int     `json:"player"`
+	Pokemon Pokemon `json:"pokemon"`
+}
+
+// Experience is what one of the winner's Pokémon gained: experience, the
+// levels it grew and, if it evolved, the Pokémon it was. Pokemon is as it
+// is after all of that.
+type Experience struct {
+	Pokemon     Pokemon `json:"pokemon"`
+	Gained      int     `json:"gained"`
+	Before      int     `json:"before"`
+	After       int     `json:"after"`
+	Levels      int     `json:"levels,omitempty"`
+	EvolvedFrom string  `json:"evolved_from,omitempty"`
+}
+
+// Reasons a game ends.
+const (
+	ReasonKnockout   = "knockout"
+	ReasonSurrender  = "surrender"
+	ReasonDisconnect = "disconnect"
+)
+
+// GameOver ends the game.
+type GameOver struct {
+	Winner int    `json:"winner"`
+	Reason string `json:"reason"`
+}
+
+// Error codes.
+const (
+	ErrVersion       = "version"
+	ErrBadMessage    = "bad_message"
+	ErrInvalidChoice = "invalid_choice"
+	ErrInvalidAction = "invalid_action"
+	ErrRoomTaken     = "room_taken"
+	ErrNoRoom        = "no_room"
+)
+
+// Error reports a message the peer couldn't act on. Unless Code is
+// ErrVersion, the sender can try again.
+type Error struct {
+	Code string `json:"code"`
+}
+
+func (e *Error) Error() string {
+	return "protocol error: " + e.Code
+}
+
+// Conn sends and receives envelopes over a network connection. Sends may
+// come from several goroutines; receives must not.
+type Conn struct {
+	conn    net.Conn
+	dec     *json.Decoder
+	pending chan received // the message read ahead by Watch, if any
+
+	mu  sync.Mutex // guards enc
+	enc *json.Encoder
+}
+
+func NewConn(conn net.Conn) *Conn {
+	return &Conn{conn: conn, dec: json.NewDecoder(conn), enc: json.NewEncoder(conn)}
+}
+
+// Send writes a message of type t with payload, and text for people.
+func (c *Conn) Send(t Type, payload interface{}, text string) error {
+	e := Envelope{Type: t, Version: Version, Text: text}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		e.Payload = data
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enc.Encode(e)
+}
+
+// received is a message read ahead, or the error reading it.
+type received struct {
+	e   Envelope
+	err error
+}
+
+// Watch reads the next message in the background, for a peer nobody is
+// reading from yet to be noticed when it hangs up. The returned channel
+// gets the read error, or nil once a message arrives; either way the next
+// Receive returns them.
+func (c *Conn) Watch() <-chan error {
+	pending := make(chan received, 1)
+	done := make(chan error, 1)
+	c.pending = pending
+	go func() {
+		e, err := c.receive()
+		pending <- received{e, err}
+		done <- err
+	}()
+	return done
+}
+
+// Receive reads the next message. Messages of another protocol version
+// are answered with an ErrVersion Error and returned as that error.
+func (c *Conn) Receive() (Envelope, error) {
+	if c.pending != nil {
+		r := <-c.pending
+		c.pending = nil
+		return r.e, r.err
+	}
+	return c.receive()
+}
+
+func (c *Conn) receive() (Envelope, error) {
+	var e Envelope
+	if err := c.dec.Decode(&e); err != nil {
+		return e, err
+	}
+	if e.Version != Version {
+		c.Send(TypeError, Error{Code: ErrVersion}, fmt.Sprintf("protocol version %d, want %d", e.Version, Version))
+		return e, &Error{Code: ErrVersion}
+	}
+	return e, nil
+}
+
+// Expect reads the next message, which has to be of type t, into payload.
+// Error messages from the peer are returned as *Error.
+func (c *Conn) Expect(t Type, payload interface{}) (Envelope, error) {
+	e, err := c.Receive()
+	if err != nil {
+		return e, err
+	}
+	if e.Type == TypeError && t != TypeError {
+		var pe Error
+		if err := e.Decode(&pe); err != nil {
+			return e, err
+		}
+		return e, &pe
+	}
+	if e.Type != t {
+		return e, fmt.Errorf("got %s message, want %s", e.Type, t)
+	}
+	return e, e.Decode(payload)
+}
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,143 @@
+package protocol
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// pipe connects two Conns in memory.
+func pipe(t *testing.T) (*Conn, *Conn) {
+	t.Helper()
+	a, b := net.Pipe()
+	ca, cb := NewConn(a), NewConn(b)
+	t.Cleanup(func() {
+		ca.Close()
+		cb.Close()
+	})
+	return ca, cb
+}
+
+func TestSendEncoding(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+	go NewConn(a).Send(TypeChoose, Choose{Index: 2}, "Pikachu")
+
+	line, err := bufio.NewReader(b).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"choose","version":2,"payload":{"index":2},"text":"Pikachu"}` + "\n"
+	if line != want {
+		t.Errorf("sent %s, want %s", line, want)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	client, server := pipe(t)
+	messages := []struct {
+		t       Type
+		payload interface{}
+		into    interface{}
+	}{
+		{TypeHello, Hello{Name: "Duy", Lang: "vi"}, &Hello{}},
+		{TypeJoin, Join{Room: "gym", Create: true}, &Join{}},
+		{TypeAction, Action{Action: ActionAttack, Move: 3}, &Action{}},
+		{TypeTurnStart, TurnStart{Turn: 4, Moves: []Move{{Name: "Quick Attack", Type: "Normal", Category: "physical", Power: 40, Accuracy: 100, PP: 29, MaxPP: 30, Priority: 1}}}, &TurnStart{}},
+		{TypeDamage, Damage{Attacker: 2, Move: Move{Name: "Swift"}, Damage: 17, Effectiveness: 0.5, Critical: true}, &Damage{}},
+	}
+	go func() {
+		for _, m := range messages {
+			client.Send(m.t, m.payload, "")
+		}
+	}()
+	for _, m := range messages {
+		e, err := server.Expect(m.t, m.into)
+		if err != nil {
+			t.Fatalf("Expect(%s): %v", m.t, err)
+		}
+		if e.Version != Version {
+			t.Errorf("%s has version %d", m.t, e.Version)
+		}
+		if got := reflect.ValueOf(m.into).Elem().Interface(); !reflect.DeepEqual(got, m.payload) {
+			t.Errorf("%s: got %+v, want %+v", m.t, got, m.payload)
+		}
+	}
+}
+
+func TestExpect(t *testing.T) {
+	client, server := pipe(t)
+	go func() {
+		client.Send(TypeError, Error{Code: ErrRoomTaken}, "There is a room gym already.")
+		client.Send(TypeWaiting, Waiting{}, "")
+		client.Send(TypeWelcome, nil, "")
+	}()
+
+	var join Join
+	var pe *Error
+	if _, err := server.Expect(TypeJoin, &join); !errors.As(err, &pe) || pe.Code != ErrRoomTaken {
+		t.Errorf("Expect of an error message = %v, want %s", err, ErrRoomTaken)
+	}
+	if _, err := server.Expect(TypeJoin, &join); err == nil || errors.As(err, &pe) {
+		t.Errorf("Expect of another type = %v, want a type error", err)
+	}
+	var welcome Welcome
+	if _, err := server.Expect(TypeWelcome, &welcome); err == nil || !strings.Contains(err.Error(), "without payload") {
+		t.Errorf("Expect without payload = %v", err)
+	}
+}
+
+func TestReceiveRejectsOtherVersions(t *testing.T) {
+	a, b := net.Pipe()
+	server := NewConn(b)
+	defer a.Close()
+	defer server.Close()
+
+	peer := json.NewEncoder(a)
+	replies := json.NewDecoder(a)
+	go peer.Encode(Envelope{Type: TypeHello, Version: Version - 1, Payload: json.RawMessage(`{"name":"Old"}`)})
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := server.Receive()
+		errs <- err
+	}()
+	var reply Envelope
+	if err := replies.Decode(&reply); err != nil {
+		t.Fatal(err)
+	}
+	var pe Error
+	if err := reply.Decode(&pe); reply.Type != TypeError || err != nil || pe.Code != ErrVersion {
+		t.Errorf("answered %+v, want a %s error", reply, ErrVersion)
+	}
+	if err := <-errs; !errors.As(err, new(*Error)) {
+		t.Errorf("Receive = %v, want a version error", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	client, server := pipe(t)
+	read := server.Watch()
+	go client.Send(TypeJoin, Join{Room: "gym"}, "")
+	if err := <-read; err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	var join Join
+	if _, err := server.Expect(TypeJoin, &join); err != nil || join.Room != "gym" {
+		t.Errorf("Expect after Watch = %+v, %v", join, err)
+	}
+
+	read = server.Watch()
+	client.Close()
+	if err := <-read; err == nil {
+		t.Error("Watch of a closed connection reported a message")
+	}
+	if _, err := server.Receive(); err == nil {
+		t.Error("Receive after Watch saw the hang-up reported no error")
+	}
+}
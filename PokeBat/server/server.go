@@ -0,0 +1,999 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thanhduy1706/PokeDBC/PokeBat/protocol"
+	"github.com/thanhduy1706/PokeDBC/locale"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+	"github.com/thanhduy1706/PokeDBC/typechart"
+)
+
+// teamSize is how many Pokémon each player picks.
+const teamSize = 3
+
+// Pokemon is a team member in battle: the shared record, whose Moves it
+// fights with, its Stats at its level, the HP and the PP of each move it
+// has left and whether it has fainted.
+type Pokemon struct {
+	pokemon.Pokemon
+	Stats     pokemon.Attributes // see pokemon.Pokemon.CalcStats
+	HP        int
+	PP        []int // by index into Moves
+	IsFainted bool
+}
+
+// struggle is what a Pokémon with no PP left in any move attacks with. It
+// has no type, so hits everything for normal damage.
+var struggle = pokemon.Move{Name: "Struggle", Category: pokemon.Physical, Power: 50}
+
+// newTeam puts loaded Pokémon into battle with the stats of their level,
+// at full HP and PP, and with their first pokemon.MaxMoves moves.
+func newTeam(pokemons []pokemon.Pokemon) []Pokemon {
+	team := make([]Pokemon, len(pokemons))
+	for i, p := range pokemons {
+		if len(p.Moves) > pokemon.MaxMoves {
+			p.Moves = p.Moves[:pokemon.MaxMoves]
+		}
+		pp := make([]int, len(p.Moves))
+		for j, m := range p.Moves {
+			pp[j] = m.PP
+		}
+		stats := p.CalcStats()
+		team[i] = Pokemon{Pokemon: p, Stats: stats, HP: stats.HP, PP: pp}
+	}
+	return team
+}
+
+// withSpecies refreshes the species fields of team members, their base
+// stats above all, from the pokedex entry with the same key, keeping what
+// makes each one an individual. Members the pokedex doesn't have are
+// kept as loaded.
+func withSpecies(team, pokedex []pokemon.Pokemon) []pokemon.Pokemon {
+	byKey := make(map[string]pokemon.Pokemon, len(pokedex))
+	for _, species := range pokedex {
+		byKey[species.Key] = species
+	}
+	refreshed := make([]pokemon.Pokemon, len(team))
+	for i, p := range team {
+		species, ok := byKey[p.Key]
+		if !ok {
+			refreshed[i] = p
+			continue
+		}
+		species.Experience = p.Experience
+		species.Level = p.Level
+		species.IVs = p.IVs
+		species.EVs = p.EVs
+		species.Nature = p.Nature
+		species.Moves = p.Moves
+		species.ElementalEffects = p.ElementalEffects
+		// A pokedex scraped without -details has neither
+		if species.GrowthRate == "" {
+			species.GrowthRate = p.GrowthRate
+		}
+		if len(species.Abilities) == 0 {
+			species.Abilities = p.Abilities
+		}
+		p.KeepAbility(&species)
+		refreshed[i] = species
+	}
+	return refreshed
+}
+
+// setStats gives p the stats of its new level or species, and the HP its
+// max HP gained with them unless it has fainted.
+func (p *Pokemon) setStats(stats pokemon.Attributes) {
+	if !p.IsFainted {
+		p.HP += stats.HP - p.Stats.HP
+	}
+	p.Stats = stats
+}
+
+// clone copies p for a game of its own, so spending PP in one game
+// leaves the lobby's team, and every other game, at full PP.
+func (p Pokemon) clone() Pokemon {
+	p.PP = append([]int(nil), p.PP...)
+	p.Moves = append([]pokemon.Move(nil), p.Moves...)
+	return p
+}
+
+// canMove reports whether p has PP left in any of its moves.
+func (p *Pokemon) canMove() bool {
+	for _, pp := range p.PP {
+		if pp > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// useMove spends one PP of p's move i and returns the move, or struggle
+// if p has no PP left in any move.
+func (p *Pokemon) useMove(i int) pokemon.Move {
+	if !p.canMove() {
+		return struggle
+	}
+	p.PP[i]--
+	return p.Moves[i]
+}
+
+// moves is p's moves as its owner is told about them.
+func (p Pokemon) moves() []protocol.Move {
+	moves := make([]protocol.Move, len(p.Moves))
+	for i, m := range p.Moves {
+		moves[i] = moveView(m)
+		moves[i].PP = p.PP[i]
+	}
+	return moves
+}
+
+// moveView is a move as the players are told about it, with full PP.
+func moveView(m pokemon.Move) protocol.Move {
+	return protocol.Move{
+		Name:     m.Name,
+		Type:     m.Type,
+		Category: m.Category,
+		Power:    m.Power,
+		Accuracy: m.Accuracy,
+		PP:       m.PP,
+		MaxPP:    m.PP,
+		Priority: m.Priority,
+	}
+}
+
+// Structure representing a player, including connection and active Pokémon info.
+type Player struct {
+	Number    int // 1 or 2, as told to the client
+	Name      string
+	Pokemons  []Pokemon
+	Choices   []int // the index in the lobby's team of each of Pokemons
+	Active    int   // Index of the active Pokémon
+	Conn      *protocol.Conn
+	Lang      string // the language messages are sent in, see package locale
+	IsFainted bool
+}
+
+// Represents the game's state, including players and the turn being played.
+type GameState struct {
+	ID      int // counts the games since the server started, for the logs
+	Player1 Player
+	Player2 Player
+	Turn    int // counts from 1; both players act each turn
+
+	// What the winner's Pokémon can evolve into, see pokemon.Evolve
+	Species    []pokemon.Pokemon
+	Evolutions []pokemon.Evolution
+}
+
+// send sends the player a message whose text is the catalog message id in
+// the player's language.
+func (player *Player) send(t protocol.Type, payload interface{}, id string, args ...interface{}) {
+	player.sendText(t, payload, locale.Sprintf(player.Lang, id, args...))
+}
+
+// sendText sends the player a message with text in their language already.
+func (player *Player) sendText(t protocol.Type, payload interface{}, text string) {
+	if err := player.Conn.Send(t, payload, text); err != nil {
+		fmt.Printf("Error sending %s to %s: %v\n", t, player.Name, err)
+	}
+}
+
+// sendError tells the player their last message couldn't be acted on.
+func (player *Player) sendError(code, id string, args ...interface{}) {
+	player.send(protocol.TypeError, protocol.Error{Code: code}, id, args...)
+}
+
+// expect reads the player's next message of type t into payload. Other
+// messages are answered with a bad_message error until it comes; only
+// connection and version errors are returned.
+func (player *Player) expect(t protocol.Type, payload interface{}) error {
+	for {
+		e, err := player.Conn.Receive()
+		if err != nil {
+			return err
+		}
+		if e.Type == t && e.Decode(payload) == nil {
+			return nil
+		}
+		player.sendError(protocol.ErrBadMessage, "message.invalid", t)
+	}
+}
+
+// name is a Pokémon's name in the player's language.
+func (player *Player) name(p Pokemon) string {
+	return p.LocalName(player.Lang)
+}
+
+// view is a Pokémon as the player is told about it.
+func (player *Player) view(p Pokemon) protocol.Pokemon {
+	return protocol.Pokemon{
+		Key:   p.Key,
+		Name:  player.name(p),
+		Types: p.Type,
+		Level: p.Level,
+		HP:    p.HP,
+		MaxHP: p.Stats.HP,
+	}
+}
+
+// active is the player's Pokémon in battle.
+func (player *Player) active() *Pokemon {
+	return &player.Pokemons[player.Active]
+}
+
+// Handle the player's Hello: their name and the language they want
+// messages in, e.g. {"name": "Duy", "lang": "vi"}.
+func handlePlayerName(player *Player) error {
+	var hello protocol.Hello
+	if err := player.expect(protocol.TypeHello, &hello); err != nil {
+		return err
+	}
+
+	player.Lang = locale.Parse(hello.Lang)
+	if name := strings.TrimSpace(hello.Name); name != "" {
+		player.Name = name
+	}
+	return nil
+}
+
+func handlePokemonSelection(player *Player, pokedex []Pokemon) error {
+	choices := make([]protocol.Pokemon, len(pokedex))
+	for i, p := range pokedex {
+		choices[i] = player.view(p)
+		choices[i].Moves = p.moves()
+	}
+	player.send(protocol.TypeWelcome, protocol.Welcome{Name: player.Name, Choices: choices, TeamSize: teamSize}, "welcome", player.Name)
+
+	player.Pokemons = make([]Pokemon, 0, teamSize) // Initialize a slice to store the Pokémon choices.
+	selectedIndexes := make(map[int]bool)          // Map to track already selected Pokémon indexes.
+	player.Choices = make([]int, 0, teamSize)
+
+	// Wait for the player's Pokémon choices.
+	for len(player.Pokemons) < teamSize {
+		var choice protocol.Choose
+		if err := player.expect(protocol.TypeChoose, &choice); err != nil {
+			return err
+		}
+		if choice.Index < 0 || choice.Index >= len(pokedex) || selectedIndexes[choice.Index] {
+			// If the choice is invalid or already selected, reject the selection
+			player.sendError(protocol.ErrInvalidChoice, "choice.invalid")
+			continue
+		}
+
+		// Add the selected Pokémon to the player's collection and mark it as selected
+		p := pokedex[choice.Index].clone()
+		player.Pokemons = append(player.Pokemons, p)
+		player.Choices = append(player.Choices, choice.Index)
+		selectedIndexes[choice.Index] = true
+
+		// Notify the player of their choice
+		slot := len(player.Pokemons)
+		chosen := protocol.Chosen{Slot: slot, Pokemon: player.view(p)}
+		chosen.Pokemon.Moves = p.moves()
+		player.send(protocol.TypeChosen, chosen, "choice.ok", player.name(p), slot)
+	}
+	return nil
+}
+
+// criticalChance is the chance of a move landing a critical hit, one in
+// criticalChance, which does criticalBonus times the damage.
+const (
+	criticalChance = 24
+	criticalBonus  = 1.5
+)
+
+// typeBonus is the attacker's multiplier for its moves of moveType: its
+// ElementalEffects entry for the type if there is one, else 1.5 for moves
+// of one of its own types (the same-type attack bonus) and 1 for others.
+func typeBonus(attacker Pokemon, moveType string) float64 {
+	if bonus, ok := attacker.ElementalEffects[strings.ToLower(moveType)]; ok {
+		return bonus
+	}
+	for _, t := range attacker.Type {
+		if strings.EqualFold(t, moveType) {
+			return 1.5
+		}
+	}
+	return 1
+}
+
+// Calculate damage dealt by a move based on the Pokémon's stats and types,
+// with the main series formula:
+//
+//	damage = ((2*level/5 + 2) * power * attack/defense / 50 + 2) * modifiers
+//
+// The modifiers are a critical hit, a random 85-100%, the attacker's
+// typeBonus and how effective the move's type is against the defender's,
+// which is returned as well. Moves the defender is immune to do no damage;
+// any other does at least 1. The random rolls come from r, or the
+// math/rand functions if r is nil.
+func calculateDamage(attacker, defender Pokemon, move pokemon.Move, r *rand.Rand) (damage int, effectiveness float64, critical bool) {
+	intn := rand.Intn
+	if r != nil {
+		intn = r.Intn
+	}
+	effectiveness = typechart.Effectiveness(move.Type, defender.Type...)
+	if effectiveness == 0 {
+		return 0, 0, false
+	}
+
+	attackStat := attacker.Stats.Attack
+	defenseStat := defender.Stats.Defense
+
+	if move.Category == pokemon.Special {
+		attackStat = attacker.Stats.SpAttack
+		defenseStat = defender.Stats.SpDefense
+	}
+	if defenseStat < 1 {
+		defenseStat = 1
+	}
+	level := attacker.Level
+	if level < 1 {
+		level = 1
+	}
+
+	base := float64((2*level/5+2)*move.Power*attackStat/defenseStat)/50 + 2
+	modifier := (85 + float64(intn(16))) / 100 * typeBonus(attacker, move.Type) * effectiveness
+	if critical = intn(criticalChance) == 0; critical {
+		modifier *= criticalBonus
+	}
+
+	damage = int(base * modifier)
+	if damage < 1 {
+		damage = 1
+	}
+	return damage, effectiveness, critical
+}
+
+// effectivenessMessage is the catalog ID of what to tell players about a
+// move's effectiveness, or "" for a normal hit.
+func effectivenessMessage(effectiveness float64) string {
+	switch {
+	case effectiveness > 1:
+		return "effective.super"
+	case effectiveness > 0 && effectiveness < 1:
+		return "effective.not"
+	}
+	return ""
+}
+
+// nextPokemon is the index of the player's next Pokémon after the active
+// one that hasn't fainted. It reports false if there is none.
+func nextPokemon(player *Player) (int, bool) {
+	for i := 1; i < len(player.Pokemons); i++ {
+		next := (player.Active + i) % len(player.Pokemons)
+		if !player.Pokemons[next].IsFainted {
+			return next, true
+		}
+	}
+	return 0, false
+}
+
+// switchPokemon makes the player's next Pokémon that hasn't fainted the
+// active one. It reports false if there is none.
+func switchPokemon(player *Player) bool {
+	next, ok := nextPokemon(player)
+	if ok {
+		player.Active = next
+	}
+	return ok
+}
+
+// announceSwitch tells both players about player's new active Pokémon.
+func announceSwitch(player, opponent *Player) {
+	p := *player.active()
+	player.send(protocol.TypeSwitch, protocol.Switch{Player: player.Number, Pokemon: player.view(p)}, "switched", player.name(p))
+	opponent.send(protocol.TypeSwitch, protocol.Switch{Player: player.Number, Pokemon: opponent.view(p)}, "switched.opponent", opponent.name(p))
+}
+
+// gameOver tells both players who won and why.
+func gameOver(winner, loser *Player, reason string) {
+	over := protocol.GameOver{Winner: winner.Number, Reason: reason}
+	switch reason {
+	case protocol.ReasonSurrender:
+		winner.send(protocol.TypeGameOver, over, "surrender.opponent")
+		loser.send(protocol.TypeGameOver, over, "surrender")
+	case protocol.ReasonDisconnect:
+		winner.send(protocol.TypeGameOver, over, "disconnect.opponent")
+	default:
+		winner.send(protocol.TypeGameOver, over, "win")
+		loser.send(protocol.TypeGameOver, over, "lose")
+	}
+}
+
+// readAction waits for a valid action from the player, answering invalid
+// ones with an error so the player can try again. Nothing is done yet;
+// see handleBattle.
+func readAction(gameState *GameState, player *Player) (protocol.Action, error) {
+	for {
+		var action protocol.Action
+		if err := player.expect(protocol.TypeAction, &action); err != nil {
+			return action, err
+		}
+		fmt.Printf("Game %d: received action %s from %s\n", gameState.ID, action.Action, player.Name)
+
+		switch action.Action {
+		case protocol.ActionAttack:
+			p := player.active()
+			switch {
+			case !p.canMove():
+				return action, nil // struggles
+			case action.Move < 0 || action.Move >= len(p.Moves):
+				player.sendError(protocol.ErrInvalidAction, "move.invalid")
+			case p.PP[action.Move] == 0:
+				player.sendError(protocol.ErrInvalidAction, "move.nopp", p.Moves[action.Move].Name)
+			default:
+				return action, nil
+			}
+		case protocol.ActionSurrender:
+			return action, nil
+		case protocol.ActionSwitch:
+			if _, ok := nextPokemon(player); ok {
+				return action, nil
+			}
+			player.sendError(protocol.ErrInvalidAction, "switch.none")
+		default:
+			player.sendError(protocol.ErrInvalidAction, "action.invalid")
+		}
+	}
+}
+
+// turnAction is the action a player chose for a turn.
+type turnAction struct {
+	player, opponent *Player
+	action           protocol.Action
+	tiebreak         int // random, orders actions that tie otherwise
+	err              error
+}
+
+// bracket ranks the kinds of action: surrendering ends the game before
+// anything else happens, and switches go before any move.
+func (a turnAction) bracket() int {
+	switch a.action.Action {
+	case protocol.ActionSurrender:
+		return 2
+	case protocol.ActionSwitch:
+		return 1
+	}
+	return 0
+}
+
+// priority is the priority of the move an attack will use.
+func (a turnAction) priority() int {
+	p := a.player.active()
+	if a.action.Action != protocol.ActionAttack || !p.canMove() {
+		return 0
+	}
+	return p.Moves[a.action.Move].Priority
+}
+
+// goesFirst orders a turn's actions the way the main games do: by
+// bracket, then by move priority, then by the current Speed of the
+// players' active Pokémon, then at random.
+func goesFirst(a, b turnAction) bool {
+	if a.bracket() != b.bracket() {
+		return a.bracket() > b.bracket()
+	}
+	if a.priority() != b.priority() {
+		return a.priority() > b.priority()
+	}
+	if sa, sb := a.player.active().Stats.Speed, b.player.active().Stats.Speed; sa != sb {
+		return sa > sb
+	}
+	return a.tiebreak < b.tiebreak
+}
+
+// readActions asks both players for their action at once and waits for
+// both. If a player's connection fails, that player is returned with the
+// error without waiting for the other.
+func readActions(gameState *GameState, turn int) ([]turnAction, *Player, error) {
+	players := []*Player{&gameState.Player1, &gameState.Player2}
+	results := make(chan turnAction, len(players))
+	for i, player := range players {
+		player.send(protocol.TypeTurnStart, protocol.TurnStart{Turn: turn, Moves: player.active().moves()}, "turn.choose", turn)
+		go func(player, opponent *Player) {
+			action, err := readAction(gameState, player)
+			results <- turnAction{player: player, opponent: opponent, action: action, tiebreak: rand.Int(), err: err}
+		}(player, players[1-i])
+	}
+
+	actions := make([]turnAction, 0, len(players))
+	for range players {
+		a := <-results
+		if a.err != nil {
+			return nil, a.player, a.err
+		}
+		actions = append(actions, a)
+	}
+	return actions, nil, nil
+}
+
+// attack has the player's active Pokémon use a move on the opponent's and
+// tells both players how it went.
+func attack(player, opponent *Player, moveIndex int) {
+	attacker, defender := player.active(), opponent.active()
+	move := attacker.useMove(moveIndex)
+
+	// A move with an accuracy of 0 never misses; status moves do no damage.
+	missed := move.Accuracy > 0 && rand.Intn(100) >= move.Accuracy
+	damage, effectiveness, critical := 0, 1.0, false
+	if !missed && move.Category != pokemon.Status {
+		damage, effectiveness, critical = calculateDamage(*attacker, *defender, move, nil)
+	}
+
+	defender.HP -= damage
+	if defender.HP < 0 {
+		defender.HP = 0
+	}
+
+	for _, p := range []*Player{player, opponent} {
+		report := protocol.Damage{
+			Attacker:      player.Number,
+			From:          p.view(*attacker),
+			To:            p.view(*defender),
+			Move:          moveView(move),
+			Damage:        damage,
+			Missed:        missed,
+			Effectiveness: effectiveness,
+			Critical:      critical,
+		}
+		var text string
+		switch {
+		case missed:
+			text = locale.Sprintf(p.Lang, "attack.missed", p.name(*attacker), move.Name)
+		case move.Category == pokemon.Status:
+			text = locale.Sprintf(p.Lang, "attack.status", p.name(*attacker), move.Name)
+		case effectiveness == 0:
+			text = locale.Sprintf(p.Lang, "attack.immune", p.name(*attacker), move.Name, p.name(*defender))
+		case p == player:
+			text = locale.Sprintf(p.Lang, "attack.dealt", p.name(*attacker), move.Name, damage, p.name(*defender), defender.HP)
+		default:
+			text = locale.Sprintf(p.Lang, "attack.taken", p.name(*attacker), move.Name, damage, p.name(*defender), defender.HP)
+		}
+		if critical {
+			text += " " + locale.Sprintf(p.Lang, "critical")
+		}
+		if id := effectivenessMessage(effectiveness); id != "" && !missed {
+			text += " " + locale.Sprintf(p.Lang, id)
+		}
+		p.sendText(protocol.TypeDamage, report, text)
+	}
+
+	// Check if the opponent's active Pokémon fainted.
+	if defender.HP == 0 {
+		defender.IsFainted = true // Mark the Pokémon as fainted with a flag.
+		opponent.send(protocol.TypeFaint, protocol.Faint{Player: opponent.Number, Pokemon: opponent.view(*defender)}, "fainted", opponent.name(*defender))
+		player.send(protocol.TypeFaint, protocol.Faint{Player: opponent.Number, Pokemon: player.view(*defender)}, "fainted.opponent", player.name(*defender))
+	}
+}
+
+// handleBattle plays turns until one player wins. Each turn both players
+// choose an action, then the actions are carried out in goesFirst order;
+// a Pokémon that faints before its turn doesn't act, and is replaced by
+// the next one at the end of the turn. It returns the winner, whose
+// Pokémon gained experience, or nil if a player disconnected.
+func handleBattle(gameState *GameState) *Player {
+	defer gameState.Player1.Conn.Close()
+	defer gameState.Player2.Conn.Close()
+
+	for gameState.Turn = 1; ; gameState.Turn++ {
+		actions, quitter, err := readActions(gameState, gameState.Turn)
+		if err != nil {
+			fmt.Printf("Game %d: error reading action: %v\n", gameState.ID, err)
+			winner := &gameState.Player1
+			if quitter == winner {
+				winner = &gameState.Player2
+			}
+			gameOver(winner, quitter, protocol.ReasonDisconnect)
+			return nil
+		}
+		sort.Slice(actions, func(i, j int) bool { return goesFirst(actions[i], actions[j]) })
+
+		// Handle the actions: surrender, switch, or attack.
+		for _, a := range actions {
+			switch a.action.Action {
+			case protocol.ActionSurrender:
+				distributeExperience(gameState, a.opponent, a.player)
+				gameOver(a.opponent, a.player, protocol.ReasonSurrender)
+				return a.opponent
+
+			case protocol.ActionSwitch:
+				switchPokemon(a.player)
+				announceSwitch(a.player, a.opponent)
+
+			case protocol.ActionAttack:
+				if a.player.active().IsFainted {
+					continue
+				}
+				attack(a.player, a.opponent, a.action.Move)
+
+				// End the game if all of the opponent's Pokémon have fainted.
+				if _, ok := nextPokemon(a.opponent); !ok && a.opponent.active().IsFainted {
+					distributeExperience(gameState, a.player, a.opponent)
+					gameOver(a.player, a.opponent, protocol.ReasonKnockout)
+					return a.player
+				}
+			}
+		}
+
+		// Send out the next Pokémon in place of fainted ones.
+		for _, a := range actions {
+			if a.player.active().IsFainted {
+				switchPokemon(a.player)
+				announceSwitch(a.player, a.opponent)
+			}
+		}
+	}
+}
+
+// distributeExperience shares the losing team's experience among the
+// winning team, whose Pokémon then level up and evolve as far as it takes
+// them.
+func distributeExperience(gameState *GameState, winningPlayer, losingPlayer *Player) {
+	// Calculate the total experience from the losing team's Pokémon.
+	totalExp := 0
+	for _, pkmn := range losingPlayer.Pokemons {
+		totalExp += pkmn.Experience
+	}
+
+	// Each Pokémon in the winning team gets an equal share of the total experience.
+	expShare := totalExp / len(winningPlayer.Pokemons)
+
+	for i := range winningPlayer.Pokemons {
+		p := &winningPlayer.Pokemons[i]
+		beforeExp := p.Experience
+		p.Experience += expShare
+		text := locale.Sprintf(winningPlayer.Lang, "exp.gained", winningPlayer.name(*p), expShare, beforeExp, p.Experience)
+
+		levels := p.LevelUp()
+		if levels > 0 {
+			p.setStats(p.CalcStats())
+			text += " " + locale.Sprintf(winningPlayer.Lang, "level.up", winningPlayer.name(*p), p.Level)
+		}
+		evolvedFrom := ""
+		if evolved, stats, ok := p.Evolve(gameState.Species, gameState.Evolutions, ""); ok {
+			evolvedFrom = winningPlayer.name(*p)
+			p.Pokemon = evolved
+			p.setStats(stats)
+			text += " " + locale.Sprintf(winningPlayer.Lang, "evolved", evolvedFrom, winningPlayer.name(*p))
+		}
+
+		winningPlayer.sendText(protocol.TypeExperience, protocol.Experience{
+			Pokemon:     winningPlayer.view(*p),
+			Gained:      expShare,
+			Before:      beforeExp,
+			After:       p.Experience,
+			Levels:      levels,
+			EvolvedFrom: evolvedFrom,
+		}, text)
+	}
+}
+
+// Lobby pairs up connected players, in the queue or in named rooms, and
+// plays a game in its own goroutine for each pair.
+type Lobby struct {
+	teams      [2][]Pokemon // the Pokémon players 1 and 2 choose from
+	teamFiles  [2]string    // where winners' teams are saved, "" not to
+	species    []pokemon.Pokemon
+	evolutions []pokemon.Evolution
+
+	mu     sync.Mutex
+	queued *Player            // waiting for anyone
+	rooms  map[string]*Player // waiting in a room, by room name
+	games  int                // games started
+}
+
+func newLobby(team1, team2 []Pokemon, species []pokemon.Pokemon, evolutions []pokemon.Evolution) *Lobby {
+	return &Lobby{
+		teams:      [2][]Pokemon{team1, team2},
+		species:    species,
+		evolutions: evolutions,
+		rooms:      make(map[string]*Player),
+	}
+}
+
+// roomNames lists the rooms waiting for a player.
+func (l *Lobby) roomNames() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := make([]string, 0, len(l.rooms))
+	for name := range l.rooms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// join finds the player an opponent as asked, or leaves them waiting for
+// one, in which case it returns nil.
+func (l *Lobby) join(player *Player, join protocol.Join) (*Player, *protocol.Error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	room := strings.TrimSpace(join.Room)
+	switch {
+	case room == "":
+		if opponent := l.queued; opponent != nil {
+			l.queued = nil
+			return opponent, nil
+		}
+		l.queued = player
+		return nil, nil
+	case join.Create:
+		if _, ok := l.rooms[room]; ok {
+			return nil, &protocol.Error{Code: protocol.ErrRoomTaken}
+		}
+		l.rooms[room] = player
+		return nil, nil
+	default:
+		opponent, ok := l.rooms[room]
+		if !ok {
+			return nil, &protocol.Error{Code: protocol.ErrNoRoom}
+		}
+		delete(l.rooms, room)
+		return opponent, nil
+	}
+}
+
+// waiting reports whether player is in the queue or a room. l.mu is held.
+func (l *Lobby) waiting(player *Player) bool {
+	if l.queued == player {
+		return true
+	}
+	for _, p := range l.rooms {
+		if p == player {
+			return true
+		}
+	}
+	return false
+}
+
+// leave takes player out of the queue or their room. l.mu is held.
+func (l *Lobby) leave(player *Player) {
+	if l.queued == player {
+		l.queued = nil
+	}
+	for room, p := range l.rooms {
+		if p == player {
+			delete(l.rooms, room)
+		}
+	}
+}
+
+// wait watches the connection of a waiting player, whose next message
+// Watch is reading, until they are matched. A player who hangs up first
+// leaves the queue or their room, so nobody is matched with them.
+func (l *Lobby) wait(player *Player, read <-chan error) {
+	for {
+		err := <-read
+		l.mu.Lock()
+		if !l.waiting(player) {
+			// Matched: the game reads from here on.
+			l.mu.Unlock()
+			return
+		}
+		if err != nil {
+			l.leave(player)
+			l.mu.Unlock()
+			fmt.Printf("%s left while waiting: %v\n", player.Name, err)
+			player.Conn.Close()
+			return
+		}
+		// Nothing is expected before the game starts; drop the message.
+		player.Conn.Receive()
+		read = player.Conn.Watch()
+		l.mu.Unlock()
+	}
+}
+
+// handleConnection greets a new player and finds them an opponent. The
+// player matched second plays the game on their goroutine; the first one's
+// goroutine waits with them, see wait.
+func (l *Lobby) handleConnection(conn net.Conn) {
+	player := &Player{Name: "Player", Conn: protocol.NewConn(conn), Lang: locale.Default}
+	if err := handlePlayerName(player); err != nil {
+		fmt.Println("Error receiving player name:", err)
+		player.Conn.Close()
+		return
+	}
+	player.send(protocol.TypeLobby, protocol.Lobby{Rooms: l.roomNames()}, "lobby", player.Name)
+
+	for {
+		var join protocol.Join
+		if err := player.expect(protocol.TypeJoin, &join); err != nil {
+			fmt.Printf("Error receiving %s's room: %v\n", player.Name, err)
+			player.Conn.Close()
+			return
+		}
+		room := strings.TrimSpace(join.Room)
+		// Read ahead before joining: once the player waits, an opponent's
+		// game may start reading from them at any time.
+		read := player.Conn.Watch()
+		opponent, err := l.join(player, join)
+		if err != nil {
+			id := "room.taken"
+			if err.Code == protocol.ErrNoRoom {
+				id = "room.none"
+			}
+			player.sendError(err.Code, id, room)
+			continue
+		}
+		if opponent != nil {
+			l.playGame(opponent, player)
+			return
+		}
+		if room == "" {
+			player.send(protocol.TypeWaiting, protocol.Waiting{}, "lobby.queued")
+		} else {
+			player.send(protocol.TypeWaiting, protocol.Waiting{Room: room}, "lobby.room", room)
+		}
+		fmt.Printf("%s is waiting for an opponent\n", player.Name)
+		l.wait(player, read)
+		return
+	}
+}
+
+// playGame has two players pick their teams, at the same time, and battle.
+func (l *Lobby) playGame(player1, player2 *Player) {
+	l.mu.Lock()
+	l.games++
+	gameState := &GameState{ID: l.games, Player1: *player1, Player2: *player2, Species: l.species, Evolutions: l.evolutions}
+	teams := l.teams
+	l.mu.Unlock()
+	fmt.Printf("Game %d: %s vs %s\n", gameState.ID, player1.Name, player2.Name)
+
+	// Tell each client its player number, then wait for both teams. The
+	// first player to hang up while picking loses, and their opponent's
+	// connection is closed too so that picking stops at once.
+	players := []*Player{&gameState.Player1, &gameState.Player2}
+	var quit sync.Once
+	quitted := false
+	var wg sync.WaitGroup
+	for i, player := range players {
+		player.Number = i + 1
+		opponent := players[1-i]
+		wg.Add(1)
+		go func(i int, player *Player) {
+			defer wg.Done()
+			player.send(protocol.TypeAssign, protocol.Assign{Player: player.Number, Opponent: opponent.Name}, "matched", player.Number, opponent.Name)
+			if err := handlePokemonSelection(player, teams[i]); err != nil {
+				quit.Do(func() {
+					quitted = true
+					fmt.Printf("Game %d: error receiving %s's Pokémon: %v\n", gameState.ID, player.Name, err)
+					gameOver(opponent, player, protocol.ReasonDisconnect)
+					opponent.Conn.Close()
+				})
+			}
+		}(i, player)
+	}
+	wg.Wait()
+	if quitted {
+		gameState.Player1.Conn.Close()
+		gameState.Player2.Conn.Close()
+		return
+	}
+
+	// Announce both players' first Pokémon and start the battle.
+	p1, p2 := &gameState.Player1, &gameState.Player2
+	p1.send(protocol.TypeBattleStart, protocol.BattleStart{Yours: p1.view(*p1.active()), Opponent: p1.view(*p2.active())}, "battle.begin")
+	p2.send(protocol.TypeBattleStart, protocol.BattleStart{Yours: p2.view(*p2.active()), Opponent: p2.view(*p1.active())}, "battle.begin")
+	if winner := handleBattle(gameState); winner != nil {
+		if err := l.keep(winner); err != nil {
+			fmt.Printf("Game %d: error saving %s's team: %v\n", gameState.ID, winner.Name, err)
+		}
+	}
+	fmt.Printf("Game %d over\n", gameState.ID)
+}
+
+// keep puts the winner's Pokémon, with the experience, levels and
+// evolutions they gained, back into the team they were chosen from, at
+// full HP and PP, and saves that team for the games after this one. Games
+// already under way keep the team they started with; of two winners with
+// the same Pokémon, the later one's is kept.
+func (l *Lobby) keep(winner *Player) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	i := winner.Number - 1
+	team := append([]Pokemon(nil), l.teams[i]...)
+	for j, p := range winner.Pokemons {
+		team[winner.Choices[j]] = newTeam([]pokemon.Pokemon{p.Pokemon})[0]
+	}
+	l.teams[i] = team
+	if l.teamFiles[i] == "" {
+		return nil
+	}
+
+	pokemons := make([]pokemon.Pokemon, len(team))
+	for j, p := range team {
+		pokemons[j] = p.Pokemon
+	}
+	return saveTeam(l.teamFiles[i], pokemons)
+}
+
+// saveTeam writes a team file, through a temporary file so a failed write
+// leaves the old one.
+func saveTeam(name string, pokemons []pokemon.Pokemon) error {
+	data, err := json.MarshalIndent(pokemons, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func main() {
+	rand.Seed(time.Now().UnixNano()) // Initialize random seed for gameplay randomness.
+
+	team1File := flag.String("team1", "PokeBat/pokedex_player1.json", "the Pokémon player 1 chooses from; the winners' progress is saved into it")
+	team2File := flag.String("team2", "PokeBat/pokedex_player2.json", "the Pokémon player 2 chooses from; the winners' progress is saved into it")
+	pokedexFile := flag.String("pokedex", "Pokedex/pokedex.json", "the species winners' Pokémon evolve into, as the Pokedex scraper writes them (empty to play without evolution)")
+	evolutionsFile := flag.String("evolutions", "Pokedex/evolutions.json", "the evolution charts Pokedex -details writes (empty to play without evolution)")
+	flag.Parse()
+
+	// Load Pokémon data for both players from JSON files.
+	team1, err := pokemon.Load(*team1File)
+	if err != nil {
+		fmt.Println("Error loading player 1's team:", err)
+		return
+	}
+	team2, err := pokemon.Load(*team2File)
+	if err != nil {
+		fmt.Println("Error loading player 2's team:", err)
+		return
+	}
+
+	// The Pokedex scraper's species and evolution charts, for the winners'
+	// Pokémon to evolve.
+	var species []pokemon.Pokemon
+	var evolutions []pokemon.Evolution
+	if *pokedexFile == "" || *evolutionsFile == "" {
+		fmt.Println("Playing without evolution")
+	} else {
+		if species, err = pokemon.Load(*pokedexFile); err != nil {
+			fmt.Println("Error loading species (-pokedex= to play without evolution):", err)
+			return
+		}
+		if evolutions, err = pokemon.LoadEvolutions(*evolutionsFile); err != nil {
+			fmt.Println("Error loading evolutions (-evolutions= to play without evolution):", err)
+			return
+		}
+	}
+	team1, team2 = withSpecies(team1, species), withSpecies(team2, species)
+	lobby := newLobby(newTeam(team1), newTeam(team2), species, evolutions)
+	lobby.teamFiles = [2]string{*team1File, *team2File}
+
+	// Open port 8080 for the players to connect to.
+	listener, err := net.Listen("tcp", ":8080")
+	if err != nil {
+		fmt.Println("Error starting server:", err)
+		return
+	}
+	defer listener.Close()
+
+	fmt.Println("Server started, waiting for players...")
+
+	// Every player gets a goroutine to find an opponent in the lobby.
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			fmt.Println("Error accepting connection:", err)
+			continue
+		}
+		fmt.Println("Player connected from", conn.RemoteAddr())
+		go lobby.handleConnection(conn)
+	}
+}
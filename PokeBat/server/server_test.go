@@ -0,0 +1,410 @@
+package main
+
+import (
+	"math/rand"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thanhduy1706/PokeDBC/PokeBat/protocol"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+var (
+	tackle      = pokemon.Move{Name: "Tackle", Type: "Normal", Category: pokemon.Physical, Power: 40, Accuracy: 100, PP: 35}
+	ember       = pokemon.Move{Name: "Ember", Type: "Fire", Category: pokemon.Special, Power: 40, Accuracy: 100, PP: 25}
+	vineWhip    = pokemon.Move{Name: "Vine Whip", Type: "Grass", Category: pokemon.Physical, Power: 45, Accuracy: 100, PP: 25}
+	quickAttack = pokemon.Move{Name: "Quick Attack", Type: "Normal", Category: pokemon.Physical, Power: 40, Accuracy: 100, PP: 30, Priority: 1}
+
+	charmander = pokemon.Pokemon{
+		Key: "charmander", Name: "Charmander", Type: []string{"Fire"}, Level: 20, Experience: 5460, GrowthRate: pokemon.MediumSlow,
+		Attributes: pokemon.Attributes{HP: 39, Attack: 52, Defense: 43, SpAttack: 60, SpDefense: 50, Speed: 65},
+		Moves:      []pokemon.Move{ember, tackle},
+	}
+	bulbasaur = pokemon.Pokemon{
+		Key: "bulbasaur", Name: "Bulbasaur", Type: []string{"Grass", "Poison"}, Level: 20, Experience: 5460, GrowthRate: pokemon.MediumSlow,
+		Attributes: pokemon.Attributes{HP: 45, Attack: 49, Defense: 49, SpAttack: 65, SpDefense: 65, Speed: 45},
+		Moves:      []pokemon.Move{vineWhip, tackle},
+	}
+	pikachu = pokemon.Pokemon{
+		Key: "pikachu", Name: "Pikachu", Type: []string{"Electric"}, Level: 20, Experience: 8000, GrowthRate: pokemon.MediumFast,
+		Attributes: pokemon.Attributes{HP: 35, Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50, Speed: 90},
+		Moves:      []pokemon.Move{quickAttack},
+	}
+)
+
+// client plays a PokeBat client over an in-memory connection to the
+// lobby, failing the test rather than hanging on a message that never
+// comes.
+type client struct {
+	t    *testing.T
+	conn *protocol.Conn
+}
+
+func connect(t *testing.T, l *Lobby, name string) (*client, <-chan struct{}) {
+	t.Helper()
+	c, s := net.Pipe()
+	c.SetDeadline(time.Now().Add(5 * time.Second))
+	done := make(chan struct{})
+	go func() {
+		l.handleConnection(s)
+		close(done)
+	}()
+
+	cl := &client{t: t, conn: protocol.NewConn(c)}
+	t.Cleanup(func() { cl.conn.Close() })
+	cl.send(protocol.TypeHello, protocol.Hello{Name: name})
+	cl.expect(protocol.TypeLobby, &protocol.Lobby{})
+	return cl, done
+}
+
+func (c *client) send(t protocol.Type, payload interface{}) {
+	c.t.Helper()
+	if err := c.conn.Send(t, payload, ""); err != nil {
+		c.t.Fatalf("sending %s: %v", t, err)
+	}
+}
+
+func (c *client) expect(t protocol.Type, payload interface{}) {
+	c.t.Helper()
+	if _, err := c.conn.Expect(t, payload); err != nil {
+		c.t.Fatalf("waiting for %s: %v", t, err)
+	}
+}
+
+// until skips messages up to the first of type t.
+func (c *client) until(t protocol.Type, payload interface{}) {
+	c.t.Helper()
+	if err := c.skipTo(t, payload); err != nil {
+		c.t.Fatalf("waiting for %s: %v", t, err)
+	}
+}
+
+// skipTo is until for another goroutine than the test's.
+func (c *client) skipTo(t protocol.Type, payload interface{}) error {
+	for {
+		e, err := c.conn.Receive()
+		if err != nil {
+			return err
+		}
+		if e.Type == t {
+			return e.Decode(payload)
+		}
+	}
+}
+
+// startGame connects two players through the queue and has both receive
+// their Welcome.
+func startGame(t *testing.T, l *Lobby) (p1, p2 *client, done1, done2 <-chan struct{}) {
+	t.Helper()
+	p1, done1 = connect(t, l, "Red")
+	p1.send(protocol.TypeJoin, protocol.Join{})
+	p1.expect(protocol.TypeWaiting, &protocol.Waiting{})
+
+	p2, done2 = connect(t, l, "Blue")
+	p2.send(protocol.TypeJoin, protocol.Join{})
+	for i, p := range []*client{p1, p2} {
+		var assign protocol.Assign
+		p.expect(protocol.TypeAssign, &assign)
+		if assign.Player != i+1 {
+			t.Errorf("player %d assigned number %d", i+1, assign.Player)
+		}
+		var welcome protocol.Welcome
+		p.expect(protocol.TypeWelcome, &welcome)
+		if len(welcome.Choices) != 3 || welcome.TeamSize != teamSize {
+			t.Fatalf("welcome to player %d: %+v", i+1, welcome)
+		}
+	}
+	return p1, p2, done1, done2
+}
+
+func testLobby() *Lobby {
+	team := []pokemon.Pokemon{charmander, bulbasaur, pikachu}
+	return newLobby(newTeam(team), newTeam(team), nil, nil)
+}
+
+func TestLobbyDisconnectWhilePicking(t *testing.T) {
+	l := testLobby()
+	p1, p2, done1, done2 := startGame(t, l)
+
+	p1.send(protocol.TypeChoose, protocol.Choose{Index: 0})
+	p1.expect(protocol.TypeChosen, &protocol.Chosen{})
+	p1.conn.Close()
+
+	// Player 2 hasn't picked anything and is told at once.
+	var over protocol.GameOver
+	p2.expect(protocol.TypeGameOver, &over)
+	if over.Winner != 2 || over.Reason != protocol.ReasonDisconnect {
+		t.Errorf("game over %+v, want player 2 winning by disconnect", over)
+	}
+	if _, err := p2.conn.Receive(); err == nil {
+		t.Error("player 2's connection is still open")
+	}
+	<-done1
+	<-done2
+}
+
+func TestLobbySurrender(t *testing.T) {
+	l := testLobby()
+	p1, p2, done1, done2 := startGame(t, l)
+
+	for _, p := range []*client{p1, p2} {
+		for i := 0; i < teamSize; i++ {
+			p.send(protocol.TypeChoose, protocol.Choose{Index: i})
+			p.expect(protocol.TypeChosen, &protocol.Chosen{})
+		}
+	}
+	// The connections are synchronous, so each message has to be read
+	// before the server sends the next one.
+	for _, typ := range []protocol.Type{protocol.TypeBattleStart, protocol.TypeTurnStart} {
+		p1.until(typ, new(interface{}))
+		p2.until(typ, new(interface{}))
+	}
+	p1.send(protocol.TypeAction, protocol.Action{Action: protocol.ActionSurrender})
+	p2.send(protocol.TypeAction, protocol.Action{Action: protocol.ActionAttack})
+
+	var over [2]protocol.GameOver
+	read := make(chan error)
+	go func() { read <- p2.skipTo(protocol.TypeGameOver, &over[1]) }()
+	p1.until(protocol.TypeGameOver, &over[0])
+	if err := <-read; err != nil {
+		t.Fatalf("waiting for player 2's game over: %v", err)
+	}
+	for i, o := range over {
+		if o.Winner != 2 || o.Reason != protocol.ReasonSurrender {
+			t.Errorf("player %d: game over %+v, want player 2 winning by surrender", i+1, o)
+		}
+	}
+	<-done1
+	<-done2
+
+	// The winners' experience stays with player 2's team for the next game.
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, p := range l.teams[1] {
+		if p.Experience <= l.teams[0][i].Experience {
+			t.Errorf("%s has %d experience after winning, want more than %d", p.Name, p.Experience, l.teams[0][i].Experience)
+		}
+	}
+}
+
+func TestNewTeam(t *testing.T) {
+	many := charmander
+	many.Moves = []pokemon.Move{ember, tackle, vineWhip, quickAttack, tackle}
+	team := newTeam([]pokemon.Pokemon{many})
+	p := team[0]
+	if len(p.Moves) != pokemon.MaxMoves || len(p.PP) != pokemon.MaxMoves {
+		t.Fatalf("%d moves and %d PP, want %d", len(p.Moves), len(p.PP), pokemon.MaxMoves)
+	}
+	for i, m := range p.Moves {
+		if p.PP[i] != m.PP {
+			t.Errorf("%s has %d PP, want the full %d", m.Name, p.PP[i], m.PP)
+		}
+	}
+	if want := many.CalcStats(); p.Stats != want || p.HP != want.HP {
+		t.Errorf("stats %+v, HP %d; want %+v at full HP", p.Stats, p.HP, want)
+	}
+}
+
+func TestUseMove(t *testing.T) {
+	p := newTeam([]pokemon.Pokemon{pikachu})[0]
+	p.PP[0] = 2
+	for _, want := range []string{"Quick Attack", "Quick Attack", "Struggle", "Struggle"} {
+		if got := p.useMove(0); got.Name != want {
+			t.Errorf("used %s, want %s", got.Name, want)
+		}
+	}
+	if p.PP[0] != 0 {
+		t.Errorf("%d PP left, want 0", p.PP[0])
+	}
+
+	none := newTeam([]pokemon.Pokemon{{Name: "Magikarp", Level: 5}})[0]
+	if none.canMove() || none.useMove(0).Name != "Struggle" {
+		t.Error("a Pokémon without moves doesn't struggle")
+	}
+}
+
+func TestCloneKeepsPP(t *testing.T) {
+	team := newTeam([]pokemon.Pokemon{charmander})
+	game := team[0].clone()
+	game.useMove(0)
+	game.Moves[1].Power = 1
+	if team[0].PP[0] != ember.PP || team[0].Moves[1].Power != tackle.Power {
+		t.Errorf("a game's moves changed the team's: PP %d, power %d", team[0].PP[0], team[0].Moves[1].Power)
+	}
+}
+
+func TestGoesFirst(t *testing.T) {
+	quick := bulbasaur
+	quick.Moves = []pokemon.Move{quickAttack, tackle}
+	fast := &Player{Pokemons: newTeam([]pokemon.Pokemon{charmander})} // Speed 65 against 45
+	slow := &Player{Pokemons: newTeam([]pokemon.Pokemon{quick})}
+	tired := &Player{Pokemons: newTeam([]pokemon.Pokemon{quick})}
+	tired.Pokemons[0].PP = []int{0, 0}
+
+	act := func(p *Player, action string, move, tiebreak int) turnAction {
+		return turnAction{player: p, action: protocol.Action{Action: action, Move: move}, tiebreak: tiebreak}
+	}
+	tests := []struct {
+		name        string
+		first, then turnAction
+	}{
+		{"surrender before switch", act(slow, protocol.ActionSurrender, 0, 2), act(fast, protocol.ActionSwitch, 0, 1)},
+		{"switch before a priority move", act(fast, protocol.ActionSwitch, 0, 2), act(slow, protocol.ActionAttack, 0, 1)},
+		{"priority before Speed", act(slow, protocol.ActionAttack, 0, 2), act(fast, protocol.ActionAttack, 1, 1)},
+		{"Speed", act(fast, protocol.ActionAttack, 1, 2), act(slow, protocol.ActionAttack, 1, 1)},
+		{"tiebreak", act(fast, protocol.ActionAttack, 1, 1), act(fast, protocol.ActionAttack, 0, 2)},
+		{"Struggle has no priority", act(fast, protocol.ActionAttack, 1, 2), act(tired, protocol.ActionAttack, 0, 1)},
+	}
+	for _, tt := range tests {
+		if !goesFirst(tt.first, tt.then) || goesFirst(tt.then, tt.first) {
+			t.Errorf("%s: goesFirst put %+v after %+v", tt.name, tt.first.action, tt.then.action)
+		}
+	}
+}
+
+func TestTypeBonus(t *testing.T) {
+	custom := charmander
+	custom.ElementalEffects = map[string]float64{"fire": 1.2, "water": 0.7}
+	tests := []struct {
+		attacker pokemon.Pokemon
+		moveType string
+		want     float64
+	}{
+		{charmander, "Fire", 1.5},
+		{charmander, "fire", 1.5},
+		{charmander, "Normal", 1},
+		{bulbasaur, "Poison", 1.5},
+		{bulbasaur, "Grass", 1.5},
+		{custom, "Fire", 1.2},
+		{custom, "Water", 0.7},
+		{custom, "Normal", 1},
+		{charmander, "", 1},
+	}
+	for _, tt := range tests {
+		p := newTeam([]pokemon.Pokemon{tt.attacker})[0]
+		if got := typeBonus(p, tt.moveType); got != tt.want {
+			t.Errorf("typeBonus(%s with %v, %s) = %v, want %v", tt.attacker.Name, tt.attacker.ElementalEffects, tt.moveType, got, tt.want)
+		}
+	}
+}
+
+func TestCalculateDamage(t *testing.T) {
+	gastly := pokemon.Pokemon{Key: "gastly", Name: "Gastly", Type: []string{"Ghost", "Poison"}, Level: 20,
+		Attributes: pokemon.Attributes{HP: 30, Attack: 35, Defense: 30, SpAttack: 100, SpDefense: 35, Speed: 80}}
+	weak := pokemon.Pokemon{Name: "Magikarp", Type: []string{"Water"}, Level: 1}
+	wall := pokemon.Pokemon{Name: "Wall", Type: []string{"Bug", "Poison"}, Level: 100,
+		Attributes: pokemon.Attributes{HP: 20, Attack: 10, Defense: 230, SpAttack: 10, SpDefense: 230, Speed: 5}}
+	splash := pokemon.Move{Name: "Splash Hit", Type: "Fighting", Category: pokemon.Physical, Power: 10, Accuracy: 100}
+
+	// At level 20 with no IVs, EVs or nature: Charmander has 25 Attack and
+	// 29 Sp. Atk, Bulbasaur 24 Attack, 24 Defense and 31 Sp. Def, and
+	// Charmander 22 Defense. min and max are the damage of the lowest and
+	// highest random roll without a critical hit.
+	tests := []struct {
+		name               string
+		attacker, defender pokemon.Pokemon
+		move               pokemon.Move
+		effectiveness      float64
+		min, max           int
+	}{
+		// ((2*20/5+2) * 40 * 29/31 / 50 + 2) = 9.48, * 1.5 STAB * 2
+		{"ember on bulbasaur", charmander, bulbasaur, ember, 2, 24, 28},
+		// ((2*20/5+2) * 40 * 25/24 / 50 + 2) = 10.32
+		{"tackle on bulbasaur", charmander, bulbasaur, tackle, 1, 8, 10},
+		// ((2*20/5+2) * 45 * 24/22 / 50 + 2) = 11.8, * 1.5 STAB * 0.5
+		{"vine whip on charmander", bulbasaur, charmander, vineWhip, 0.5, 7, 8},
+		{"at least 1", weak, wall, splash, 0.25, 1, 1},
+	}
+	for _, tt := range tests {
+		attacker, defender := newTeam([]pokemon.Pokemon{tt.attacker})[0], newTeam([]pokemon.Pokemon{tt.defender})[0]
+		seen := make(map[int]bool)
+		crits := 0
+		for seed := int64(1); seed <= 500; seed++ {
+			damage, effectiveness, critical := calculateDamage(attacker, defender, tt.move, rand.New(rand.NewSource(seed)))
+			again, _, _ := calculateDamage(attacker, defender, tt.move, rand.New(rand.NewSource(seed)))
+			if damage != again {
+				t.Fatalf("%s: seed %d dealt %d, then %d", tt.name, seed, damage, again)
+			}
+			if effectiveness != tt.effectiveness {
+				t.Errorf("%s: effectiveness %v, want %v", tt.name, effectiveness, tt.effectiveness)
+			}
+			min, max := tt.min, tt.max
+			if critical {
+				crits++
+				min, max = int(float64(min)*criticalBonus), int(float64(max+1)*criticalBonus)
+			} else {
+				seen[damage] = true
+			}
+			if damage < min || damage > max {
+				t.Errorf("%s: seed %d dealt %d (critical %v), want %d to %d", tt.name, seed, damage, critical, min, max)
+			}
+		}
+		if !seen[tt.min] || !seen[tt.max] {
+			t.Errorf("%s: dealt %v without critical hits, want %d to %d", tt.name, seen, tt.min, tt.max)
+		}
+		if crits == 0 || crits > 500/criticalChance*2 {
+			t.Errorf("%s: %d critical hits in 500 seeds", tt.name, crits)
+		}
+	}
+
+	attacker, defender := newTeam([]pokemon.Pokemon{charmander})[0], newTeam([]pokemon.Pokemon{gastly})[0]
+	if damage, effectiveness, critical := calculateDamage(attacker, defender, tackle, rand.New(rand.NewSource(1))); damage != 0 || effectiveness != 0 || critical {
+		t.Errorf("tackle on gastly = %d, %v, %v; want no damage", damage, effectiveness, critical)
+	}
+}
+
+// TestTeamFiles checks the bundled teams hold real species at the level
+// their experience is worth.
+func TestTeamFiles(t *testing.T) {
+	for _, name := range []string{"../pokedex_player1.json", "../pokedex_player2.json"} {
+		team, err := pokemon.Load(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(team) < teamSize {
+			t.Errorf("%s: %d Pokémon, want at least %d", name, len(team), teamSize)
+		}
+		for _, p := range team {
+			if p.Key == "" || p.Number == 0 || p.GrowthRate == "" || p.Ability == "" || len(p.Moves) == 0 {
+				t.Errorf("%s: %s is missing its key, number, growth rate, ability or moves", name, p.Name)
+			}
+			a := p.Attributes
+			if total := a.HP + a.Attack + a.Defense + a.SpAttack + a.SpDefense + a.Speed; total != p.Total || total < 200 {
+				t.Errorf("%s: %s's base stats add up to %d, total %d", name, p.Name, total, p.Total)
+			}
+			if level := pokemon.LevelForExp(p.GrowthRate, p.Experience); level != p.Level {
+				t.Errorf("%s: %s has %d experience, level %d on the %s curve, but is level %d", name, p.Name, p.Experience, level, p.GrowthRate, p.Level)
+			}
+		}
+	}
+}
+
+func TestWithSpecies(t *testing.T) {
+	owned := charmander
+	owned.Attributes = pokemon.Attributes{HP: 2, Attack: 62}
+	owned.Abilities = []pokemon.Ability{{Name: "Blaze"}, {Name: "Solar Power", Hidden: true}}
+	owned.Ability = "Solar Power"
+	owned.Nature = "modest"
+	owned.IVs = pokemon.Stats{SpAttack: 31}
+	stray := pikachu
+	stray.Key = "pikachu-cosplay"
+
+	scraped := charmander
+	scraped.Level, scraped.Experience, scraped.Moves = 1, 0, nil
+	scraped.Number = 4
+	scraped.Abilities = []pokemon.Ability{{Name: "Blaze"}, {Name: "Solar Power", Hidden: true}}
+
+	got := withSpecies([]pokemon.Pokemon{owned, stray}, []pokemon.Pokemon{scraped})
+	p := got[0]
+	if p.Attributes != charmander.Attributes || p.Number != 4 {
+		t.Errorf("base stats %+v, number %d; want the pokedex's", p.Attributes, p.Number)
+	}
+	if p.Level != 20 || p.Experience != owned.Experience || p.Nature != "modest" || p.IVs != owned.IVs || p.Ability != "Solar Power" || len(p.Moves) != 2 {
+		t.Errorf("lost the individual: %+v", p)
+	}
+	if !reflect.DeepEqual(got[1], stray) {
+		t.Errorf("a Pokémon missing from the pokedex changed: %+v", got[1])
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// parseAbilities reads the "Abilities" row of each form's Pokédex data
+// table, by entry key. Regular abilities are numbered; the hidden one is
+// set in small print. Descriptions are the links' titles.
+func parseAbilities(doc *goquery.Document) map[string][]pokemon.Ability {
+	abilities := make(map[string][]pokemon.Ability)
+	eachForm(doc, func(key string, panel *goquery.Selection) {
+		panel.Find("table.vitals-table th").EachWithBreak(func(i int, th *goquery.Selection) bool {
+			if strings.TrimSpace(th.Text()) != "Abilities" {
+				return true
+			}
+			th.Next().Find("a").Each(func(j int, a *goquery.Selection) {
+				abilities[key] = append(abilities[key], pokemon.Ability{
+					Name:        strings.TrimSpace(a.Text()),
+					Description: strings.TrimSpace(a.AttrOr("title", "")),
+					Hidden:      a.ParentsFiltered("small").Length() > 0,
+				})
+			})
+			return false
+		})
+	})
+	return abilities
+}
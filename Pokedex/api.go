@@ -0,0 +1,291 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+	"github.com/thanhduy1706/PokeDBC/store"
+)
+
+const (
+	apiAddr        = ":8090"
+	defaultPerPage = 50
+	maxPerPage     = 500
+)
+
+// api serves pokedex.json, or a store database, read-only over HTTP:
+//
+//	GET /pokemon              list, filtered and paginated (see pokemonQuery)
+//	GET /pokemon/{id}         one entry by key, name or dex number
+//	GET /types                count and average stats per type
+//	GET /types/{type}         the entries of a type, paginated like /pokemon
+//
+// pokedex.json is read once at start, so restart the server after
+// scraping; a store database is queried on each request, but the type
+// summary is still computed at start. Every response carries an ETag and
+// answers If-None-Match with 304.
+type api struct {
+	entries finder
+	types   []typeSummary
+}
+
+func newAPI(entries finder) (*api, error) {
+	all, err := entries.Find(store.Query{})
+	if err != nil {
+		return nil, err
+	}
+	return &api{entries: entries, types: typeBreakdown(all)}, nil
+}
+
+func (a *api) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pokemon", a.listPokemon)
+	mux.HandleFunc("GET /pokemon/{id}", a.getPokemon)
+	mux.HandleFunc("GET /types", a.listTypes)
+	mux.HandleFunc("GET /types/{type}", a.listType)
+
+	// front.html is served by the world servers, on another origin
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// pokemonPage is one page of a list response.
+type pokemonPage struct {
+	Total    int               `json:"total"`
+	Page     int               `json:"page"`
+	PerPage  int               `json:"per_page"`
+	Pokemons []pokemon.Pokemon `json:"pokemons"`
+}
+
+func (a *api) listPokemon(w http.ResponseWriter, r *http.Request) {
+	a.list(w, r, nil)
+}
+
+func (a *api) listType(w http.ResponseWriter, r *http.Request) {
+	t := r.PathValue("type")
+	for _, s := range a.types {
+		if strings.EqualFold(s.Type, t) {
+			a.list(w, r, []string{s.Type})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("no entries of type %q", t))
+}
+
+// list answers a list request, with types added to the query's filters.
+func (a *api) list(w http.ResponseWriter, r *http.Request, types []string) {
+	params := r.URL.Query()
+	q, err := pokemonQuery(params)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	q.Types = append(q.Types, types...)
+	page, err := intParam(params, "page", 1)
+	if err == nil && page < 1 {
+		err = fmt.Errorf("page: must be 1 or more")
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	perPage, err := intParam(params, "per_page", defaultPerPage)
+	if err == nil && (perPage < 1 || perPage > maxPerPage) {
+		err = fmt.Errorf("per_page: must be between 1 and %d", maxPerPage)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	matches, err := a.entries.Find(q)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if matches == nil {
+		matches = []pokemon.Pokemon{}
+	}
+	if by := params.Get("sort"); by != "" {
+		if err := sortPokemons(matches, by, params.Get("order") == "desc", q.Locale); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	result := pokemonPage{Total: len(matches), Page: page, PerPage: perPage, Pokemons: []pokemon.Pokemon{}}
+	if start := (page - 1) * perPage; start < len(matches) {
+		end := start + perPage
+		if end > len(matches) {
+			end = len(matches)
+		}
+		result.Pokemons = matches[start:end]
+	}
+	writeJSON(w, r, result)
+}
+
+// getPokemon looks id up as a key ("meowth-alolan"), then a name in the
+// lang parameter's language or any, then a dex number. Names and numbers
+// give the first form, the base species.
+func (a *api) getPokemon(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	p, err := a.lookup(id, r.URL.Query().Get("lang"))
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no entry for %q", id))
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		writeJSON(w, r, p)
+	}
+}
+
+func (a *api) lookup(id, lang string) (pokemon.Pokemon, error) {
+	p, err := a.entries.Get(id)
+	if !errors.Is(err, store.ErrNotFound) {
+		return p, err
+	}
+	queries := []store.Query{{Name: id, Locale: lang, Limit: 1}}
+	if number, _ := strconv.Atoi(id); number > 0 {
+		queries = append(queries, store.Query{Number: number, Limit: 1})
+	}
+	for _, q := range queries {
+		found, err := a.entries.Find(q)
+		if err != nil {
+			return pokemon.Pokemon{}, err
+		}
+		if len(found) > 0 {
+			return found[0], nil
+		}
+	}
+	return pokemon.Pokemon{}, store.ErrNotFound
+}
+
+func (a *api) listTypes(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, a.types)
+}
+
+// pokemonQuery reads the list filters: type (repeatable), name, search,
+// lang (the language name, search and sort=name use), number, min_<stat>
+// and max_<stat> for each of pokemon.StatNames, and min_base_exp /
+// max_base_exp.
+func pokemonQuery(params url.Values) (store.Query, error) {
+	q := store.Query{
+		Name:   params.Get("name"),
+		Search: params.Get("search"),
+		Locale: params.Get("lang"),
+		Types:  params["type"],
+		Stats:  make(map[string]store.Range),
+	}
+	var err error
+	if q.Number, err = intParam(params, "number", 0); err != nil {
+		return q, err
+	}
+	if q.BaseExp.Min, err = intParam(params, "min_base_exp", 0); err != nil {
+		return q, err
+	}
+	if q.BaseExp.Max, err = intParam(params, "max_base_exp", 0); err != nil {
+		return q, err
+	}
+	for _, stat := range pokemon.StatNames {
+		var r store.Range
+		if r.Min, err = intParam(params, "min_"+stat, 0); err != nil {
+			return q, err
+		}
+		if r.Max, err = intParam(params, "max_"+stat, 0); err != nil {
+			return q, err
+		}
+		if r != (store.Range{}) {
+			q.Stats[stat] = r
+		}
+	}
+	return q, nil
+}
+
+func intParam(params url.Values, name string, def int) (int, error) {
+	v := params.Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q is not a number", name, v)
+	}
+	return n, nil
+}
+
+// writeJSON sends v with an ETag of its encoding, or 304 Not Modified if
+// the client already has that version.
+func writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// etagMatches checks an If-None-Match header, which may list several
+// tags, weak ones included.
+func etagMatches(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+func runServe(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", apiAddr, "address to listen on")
+	db := dbFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	entries, done, err := openFinder(*db)
+	if err != nil {
+		return err
+	}
+	defer done()
+	a, err := newAPI(entries)
+	if err != nil {
+		return err
+	}
+	from := pokedexFile
+	if *db != "" {
+		from = *db
+	}
+	fmt.Fprintf(w, "Serving %s on %s\n", from, *addr)
+	return http.ListenAndServe(*addr, a.routes())
+}
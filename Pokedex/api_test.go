@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// apiGet sends a GET for path to the API over cliPokedex.
+func apiGet(t *testing.T, path string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	a, err := newAPI(pokedexEntries(cliPokedex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	a.routes().ServeHTTP(w, r)
+	return w
+}
+
+func pageKeys(t *testing.T, w *httptest.ResponseRecorder) (pokemonPage, []string) {
+	t.Helper()
+	var page pokemonPage
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("%v in %s", err, w.Body)
+	}
+	keys := []string{}
+	for _, p := range page.Pokemons {
+		keys = append(keys, p.Key)
+	}
+	return page, keys
+}
+
+func TestAPIList(t *testing.T) {
+	tests := []struct {
+		path  string
+		total int
+		want  []string
+	}{
+		{"/pokemon", 5, []string{"bulbasaur", "charmander", "pikachu", "raichu", "raichu-alolan"}},
+		{"/pokemon?type=electric&min_speed=100", 2, []string{"raichu", "raichu-alolan"}},
+		{"/pokemon?search=chu&sort=attack&order=desc", 3, []string{"raichu", "raichu-alolan", "pikachu"}},
+		{"/pokemon?max_base_exp=100", 2, []string{"bulbasaur", "charmander"}},
+		{"/pokemon?per_page=2", 5, []string{"bulbasaur", "charmander"}},
+		{"/pokemon?per_page=2&page=3", 5, []string{"raichu-alolan"}},
+		{"/pokemon?per_page=2&page=4", 5, []string{}},
+		{"/pokemon?per_page=500", 5, []string{"bulbasaur", "charmander", "pikachu", "raichu", "raichu-alolan"}},
+		{"/pokemon?type=dragon", 0, []string{}},
+		{"/types/psychic", 1, []string{"raichu-alolan"}},
+		{"/types/Electric?per_page=1&page=2", 3, []string{"raichu"}},
+	}
+	for _, tt := range tests {
+		w := apiGet(t, tt.path, nil)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: status %d: %s", tt.path, w.Code, w.Body)
+			continue
+		}
+		page, keys := pageKeys(t, w)
+		if page.Total != tt.total || !reflect.DeepEqual(keys, tt.want) {
+			t.Errorf("%s = %d entries %v, want %d %v", tt.path, page.Total, keys, tt.total, tt.want)
+		}
+	}
+}
+
+func TestAPIGet(t *testing.T) {
+	for path, want := range map[string]string{
+		"/pokemon/raichu-alolan": "raichu-alolan",
+		"/pokemon/Raichu":        "raichu",
+		"/pokemon/26":            "raichu",
+		"/pokemon/%E3%83%94%E3%82%AB%E3%83%81%E3%83%A5%E3%82%A6?lang=ja": "pikachu",
+	} {
+		w := apiGet(t, path, nil)
+		var p pokemon.Pokemon
+		if err := json.Unmarshal(w.Body.Bytes(), &p); w.Code != http.StatusOK || err != nil || p.Key != want {
+			t.Errorf("%s: status %d, key %q, %v; want %s", path, w.Code, p.Key, err, want)
+		}
+	}
+	for _, path := range []string{"/pokemon/mew", "/pokemon/151", "/types/dragon"} {
+		if w := apiGet(t, path, nil); w.Code != http.StatusNotFound {
+			t.Errorf("%s: status %d, want 404", path, w.Code)
+		}
+	}
+}
+
+func TestAPIBadParams(t *testing.T) {
+	for _, path := range []string{
+		"/pokemon?page=0",
+		"/pokemon?page=-1",
+		"/pokemon?page=two",
+		"/pokemon?per_page=0",
+		"/pokemon?per_page=501",
+		"/pokemon?min_speed=fast",
+		"/pokemon?max_base_exp=lots",
+		"/pokemon?number=x",
+		"/pokemon?sort=luck",
+		"/types/electric?page=0",
+	} {
+		w := apiGet(t, path, nil)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", path, w.Code)
+			continue
+		}
+		var body map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body["error"] == "" {
+			t.Errorf("%s: body %s, want an error message", path, w.Body)
+		}
+	}
+}
+
+func TestAPIETag(t *testing.T) {
+	for _, path := range []string{"/pokemon?type=fire", "/pokemon/pikachu", "/types"} {
+		w := apiGet(t, path, nil)
+		etag := w.Header().Get("ETag")
+		if w.Code != http.StatusOK || etag == "" {
+			t.Fatalf("%s: status %d, ETag %q", path, w.Code, etag)
+		}
+
+		for _, match := range []string{etag, `"other", W/` + etag, "*"} {
+			w := apiGet(t, path, http.Header{"If-None-Match": {match}})
+			if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+				t.Errorf("%s with If-None-Match %s: status %d, %d bytes; want 304 and no body", path, match, w.Code, w.Body.Len())
+			}
+			if w.Header().Get("ETag") != etag {
+				t.Errorf("%s: 304 has ETag %q, want %q", path, w.Header().Get("ETag"), etag)
+			}
+		}
+
+		if w := apiGet(t, path, http.Header{"If-None-Match": {`"other"`}}); w.Code != http.StatusOK {
+			t.Errorf("%s with a stale ETag: status %d, want 200", path, w.Code)
+		}
+	}
+
+	a, b := apiGet(t, "/pokemon?type=fire", nil), apiGet(t, "/pokemon?type=grass", nil)
+	if a.Header().Get("ETag") == b.Header().Get("ETag") {
+		t.Error("different lists have the same ETag")
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/thanhduy1706/PokeDBC/assets"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+const assetsDir = "assets"
+
+// parseArtwork reads the artwork of each form on a detail page, by entry
+// key.
+func parseArtwork(doc *goquery.Document) map[string]string {
+	artwork := make(map[string]string)
+	eachForm(doc, func(key string, panel *goquery.Selection) {
+		if src := panel.Find(`a[rel="lightbox"] img`).First().AttrOr("src", ""); src != "" {
+			artwork[key] = src
+		}
+	})
+	return artwork
+}
+
+// downloadArtwork saves the artwork of every entry into dir and records it
+// in the manifest there. Entries whose URL hasn't changed since the last
+// run are not downloaded again, and forms without artwork of their own
+// share their species'. Failed downloads are logged and skipped.
+func (s *scraper) downloadArtwork(ctx context.Context, dir string, pokemons []pokemon.Pokemon, pages map[string]detailPage) (downloaded int, err error) {
+	manifest, err := assets.Load(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	var tick <-chan time.Time
+	if s.delay > 0 {
+		ticker := time.NewTicker(s.delay)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	files := make(map[string]string) // URL -> file, for URLs seen this run
+	for _, p := range pokemons {
+		artwork := pages[p.SpeciesKey()].Artwork
+		src, ok := artwork[p.Key]
+		if !ok {
+			src, ok = artwork[p.SpeciesKey()]
+		}
+		if !ok {
+			continue
+		}
+
+		if old, ok := manifest[p.Key]; ok && old.URL == src && assets.Has(dir, old) {
+			files[src] = old.File
+			continue
+		}
+		if file, ok := files[src]; ok {
+			manifest[p.Key] = assets.Asset{File: file, URL: src}
+			continue
+		}
+
+		if tick != nil {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				return downloaded, ctx.Err()
+			}
+		}
+		body, err := s.fetch.get(ctx, src)
+		if err != nil {
+			log.Printf("Error fetching artwork for %s: %v", p.Key, err)
+			continue
+		}
+		file, err := assets.Store(dir, body, artworkExt(src))
+		if err != nil {
+			return downloaded, fmt.Errorf("storing artwork for %s: %w", p.Key, err)
+		}
+		files[src] = file
+		manifest[p.Key] = assets.Asset{File: file, URL: src}
+		downloaded++
+	}
+
+	return downloaded, manifest.Save(dir)
+}
+
+// artworkExt is the extension of the file an image URL points to.
+func artworkExt(src string) string {
+	u, err := url.Parse(src)
+	if err != nil {
+		return ""
+	}
+	return path.Ext(u.Path)
+}
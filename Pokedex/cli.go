@@ -0,0 +1,506 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+	"github.com/thanhduy1706/PokeDBC/store"
+)
+
+// commands answer questions about the saved pokedex.json, or with -db the
+// store database imported from it, e.g.
+//
+//	Pokedex filter -type grass -min speed=90
+//	Pokedex top -stat attack -n 5 -format csv -db pokedex.db
+//	Pokedex serve -addr :8090
+//
+// Without a command the Pokedex binary scrapes as before.
+var commands = map[string]struct {
+	usage string
+	run   func(args []string, w io.Writer) error
+}{
+	"search": {"search [flags] <text>\tentries whose name or form contains text", runSearch},
+	"show":   {"show [flags] <name|key|number>\tevery field of an entry and its forms", runShow},
+	"filter": {"filter [flags]\tentries matching -type, -min, -max, ...", runFilter},
+	"sort":   {"sort -by <stat> [flags]\tentries ordered by a stat", runSort},
+	"top":    {"top -stat <stat> [-n 10] [flags]\tthe entries with the highest stat", runTop},
+	"types":  {"types [flags]\tentry count and average stats per type", runTypes},
+	"serve":  {"serve [-addr :8090]\tserve the pokedex as a read-only JSON API", runServe},
+	"export": {"export [-format f] <file>\twrite the pokedex as JSON, CSV, YAML or protobuf", runExport},
+	"import": {"import [-format f] <file>\treplace " + pokedexFile + " with an exported file", runImport},
+	"names":  {"names <file>\tadd names in other languages from a JSON file", runNames},
+}
+
+// sortFields are the columns sort and top accept besides pokemon.StatNames.
+var sortFields = []string{"number", "name", "base_exp"}
+
+func sortKeys() string {
+	keys := append(append([]string(nil), pokemon.StatNames...), sortFields...)
+	return strings.Join(keys, ", ")
+}
+
+func commandUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := flag.CommandLine.Output()
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Usage: %s [flags]\tscrape the pokedex\n", os.Args[0])
+	for _, name := range names {
+		fmt.Fprintf(tw, "       %s %s\n", os.Args[0], commands[name].usage)
+	}
+	tw.Flush()
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
+}
+
+// runCommand runs args[0] as a command if it is one.
+func runCommand(args []string) (ran bool, err error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return false, nil
+	}
+	return true, cmd.run(args[1:], os.Stdout)
+}
+
+// listFlags are the filter and output flags every list command takes.
+type listFlags struct {
+	fs      *flag.FlagSet
+	db      *string
+	types   stringList
+	mins    stringList
+	maxs    stringList
+	name    *string
+	lang    *string
+	number  *int
+	minExp  *int
+	maxExp  *int
+	limit   *int
+	format  *string
+	sortBy  *string
+	reverse *bool
+}
+
+func newListFlags(name string) *listFlags {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	f := &listFlags{fs: fs}
+	f.db = dbFlag(fs)
+	fs.Var(&f.types, "type", "only entries with this type; repeat for dual types")
+	fs.Var(&f.mins, "min", "only entries with stat=value or more, e.g. speed=90; repeatable")
+	fs.Var(&f.maxs, "max", "only entries with stat=value or less; repeatable")
+	f.name = fs.String("name", "", "only entries with this name, forms included")
+	f.lang = fs.String("lang", "", "match and print names in this language, e.g. ja or fr; names in any language match without it")
+	f.number = fs.Int("number", 0, "only entries with this national dex number")
+	f.minExp = fs.Int("min-exp", 0, "only entries with at least this base exp")
+	f.maxExp = fs.Int("max-exp", 0, "only entries with at most this base exp")
+	f.limit = fs.Int("limit", 0, "print at most this many entries")
+	f.format = fs.String("format", "table", "output format: table, json or csv")
+	f.sortBy = fs.String("by", "", "order by this stat: "+sortKeys())
+	f.reverse = fs.Bool("desc", false, "with -by, highest first")
+	return f
+}
+
+func (f *listFlags) query() (store.Query, error) {
+	q := store.Query{
+		Name:    *f.name,
+		Locale:  *f.lang,
+		Number:  *f.number,
+		Types:   f.types,
+		BaseExp: store.Range{Min: *f.minExp, Max: *f.maxExp},
+		Stats:   make(map[string]store.Range),
+	}
+	for _, bound := range []struct {
+		values stringList
+		max    bool
+	}{{f.mins, false}, {f.maxs, true}} {
+		for _, v := range bound.values {
+			stat, value, err := parseStatValue(v)
+			if err != nil {
+				return q, err
+			}
+			r := q.Stats[stat]
+			if bound.max {
+				r.Max = value
+			} else {
+				r.Min = value
+			}
+			q.Stats[stat] = r
+		}
+	}
+	return q, nil
+}
+
+// parseStatValue splits "speed=90".
+func parseStatValue(s string) (string, int, error) {
+	stat, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", 0, fmt.Errorf("%q: want stat=value", s)
+	}
+	stat = strings.ToLower(strings.TrimSpace(stat))
+	if _, ok := (pokemon.Pokemon{}).Stat(stat); !ok {
+		return "", 0, fmt.Errorf("unknown stat %q, want one of %s", stat, strings.Join(pokemon.StatNames, ", "))
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return "", 0, fmt.Errorf("%q: %w", s, err)
+	}
+	return stat, n, nil
+}
+
+// dbFlag adds the -db flag of the commands that read the pokedex.
+func dbFlag(fs *flag.FlagSet) *string {
+	return fs.String("db", "", "read this store database, e.g. pokedex.db, instead of "+pokedexFile)
+}
+
+// list loads the pokedex, applies the filters and order, and prints the
+// result.
+func (f *listFlags) list(w io.Writer, q store.Query) error {
+	matches, err := findPokemons(*f.db, q)
+	if err != nil {
+		return err
+	}
+	if *f.sortBy != "" {
+		if err := sortPokemons(matches, *f.sortBy, *f.reverse, *f.lang); err != nil {
+			return err
+		}
+	}
+	if *f.limit > 0 && len(matches) > *f.limit {
+		matches = matches[:*f.limit]
+	}
+	return writePokemons(w, *f.format, *f.lang, matches)
+}
+
+func runFilter(args []string, w io.Writer) error {
+	f := newListFlags("filter")
+	if err := f.fs.Parse(args); err != nil {
+		return err
+	}
+	q, err := f.query()
+	if err != nil {
+		return err
+	}
+	return f.list(w, q)
+}
+
+func runSearch(args []string, w io.Writer) error {
+	f := newListFlags("search")
+	if err := f.fs.Parse(args); err != nil {
+		return err
+	}
+	if f.fs.NArg() == 0 {
+		return errors.New("search: missing text to search for")
+	}
+	q, err := f.query()
+	if err != nil {
+		return err
+	}
+	q.Search = strings.Join(f.fs.Args(), " ")
+	return f.list(w, q)
+}
+
+func runSort(args []string, w io.Writer) error {
+	f := newListFlags("sort")
+	if err := f.fs.Parse(args); err != nil {
+		return err
+	}
+	if *f.sortBy == "" {
+		return errors.New("sort: missing -by")
+	}
+	q, err := f.query()
+	if err != nil {
+		return err
+	}
+	return f.list(w, q)
+}
+
+func runTop(args []string, w io.Writer) error {
+	f := newListFlags("top")
+	stat := f.fs.String("stat", "total", "the stat to rank by")
+	n := f.fs.Int("n", 10, "how many entries to print")
+	if err := f.fs.Parse(args); err != nil {
+		return err
+	}
+	q, err := f.query()
+	if err != nil {
+		return err
+	}
+	*f.sortBy, *f.reverse, *f.limit = *stat, true, *n
+	return f.list(w, q)
+}
+
+func runShow(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	format := fs.String("format", "table", "output format: table, json or csv")
+	lang := fs.String("lang", "", "match and print the name in this language")
+	db := dbFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("show: missing name, key or number")
+	}
+	arg := strings.Join(fs.Args(), " ")
+
+	entries, done, err := openFinder(*db)
+	if err != nil {
+		return err
+	}
+	defer done()
+	matches, err := showMatches(entries, arg, *lang)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("no entry for %q", arg)
+	}
+
+	if *format != "table" {
+		return writePokemons(w, *format, *lang, matches)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, p := range matches {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "Key\t%s\n", p.Key)
+		fmt.Fprintf(tw, "Number\t%d\n", p.Number)
+		fmt.Fprintf(tw, "Name\t%s\n", displayName(p, *lang))
+		if len(p.Names) > 0 {
+			fmt.Fprintf(tw, "Other names\t%s\n", formatNames(p.Names))
+		}
+		fmt.Fprintf(tw, "Type\t%s\n", strings.Join(p.Type, "/"))
+		fmt.Fprintf(tw, "Base exp\t%d\n", p.BaseExp)
+		fmt.Fprintf(tw, "Growth rate\t%s\n", p.GrowthRate)
+		for _, a := range p.Abilities {
+			label := "Ability"
+			if a.Hidden {
+				label = "Hidden ability"
+			}
+			fmt.Fprintf(tw, "%s\t%s: %s\n", label, a.Name, a.Description)
+		}
+		for _, stat := range pokemon.StatNames {
+			v, _ := p.Stat(stat)
+			fmt.Fprintf(tw, "%s\t%d\n", statLabels[stat], v)
+		}
+		fmt.Fprintf(tw, "EV yield\t%s\n", formatYield(p.EVYield))
+	}
+	return tw.Flush()
+}
+
+func runTypes(args []string, w io.Writer) error {
+	f := newListFlags("types")
+	if err := f.fs.Parse(args); err != nil {
+		return err
+	}
+	q, err := f.query()
+	if err != nil {
+		return err
+	}
+	matches, err := findPokemons(*f.db, q)
+	if err != nil {
+		return err
+	}
+	return writeTypeSummaries(w, *f.format, typeBreakdown(matches))
+}
+
+// findPokemons returns the entries q matches, in the store database at
+// db or, if db is empty, in pokedex.json.
+func findPokemons(db string, q store.Query) ([]pokemon.Pokemon, error) {
+	entries, done, err := openFinder(db)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return entries.Find(q)
+}
+
+// showMatches returns the entries show prints for arg: the one with arg
+// as its key, and those with arg as their name in lang (or any language)
+// or as their dex number, in dex order.
+func showMatches(entries finder, arg, lang string) ([]pokemon.Pokemon, error) {
+	found, err := entries.Find(store.Query{Name: arg, Locale: lang})
+	if err != nil {
+		return nil, err
+	}
+	if number, _ := strconv.Atoi(arg); number > 0 {
+		byNumber, err := entries.Find(store.Query{Number: number})
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, byNumber...)
+	}
+	if p, err := entries.Get(arg); err == nil {
+		found = append(found, p)
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var matches []pokemon.Pokemon
+	for _, p := range found {
+		if !seen[p.Key] {
+			seen[p.Key] = true
+			matches = append(matches, p)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Number < matches[j].Number })
+	return matches, nil
+}
+
+// sortPokemons orders pokemons by a stat or one of sortFields, keeping dex
+// order between equal values. Names sort in locale.
+func sortPokemons(pokemons []pokemon.Pokemon, by string, desc bool, locale string) error {
+	by = strings.ToLower(by)
+	var less func(a, b pokemon.Pokemon) bool
+	switch by {
+	case "number":
+		less = func(a, b pokemon.Pokemon) bool { return a.Number < b.Number }
+	case "name":
+		less = func(a, b pokemon.Pokemon) bool { return displayName(a, locale) < displayName(b, locale) }
+	case "base_exp":
+		less = func(a, b pokemon.Pokemon) bool { return a.BaseExp < b.BaseExp }
+	default:
+		if _, ok := (pokemon.Pokemon{}).Stat(by); !ok {
+			return fmt.Errorf("can't sort by %q, want one of %s", by, sortKeys())
+		}
+		less = func(a, b pokemon.Pokemon) bool {
+			x, _ := a.Stat(by)
+			y, _ := b.Stat(by)
+			return x < y
+		}
+	}
+	sort.SliceStable(pokemons, func(i, j int) bool {
+		if desc {
+			return less(pokemons[j], pokemons[i])
+		}
+		return less(pokemons[i], pokemons[j])
+	})
+	return nil
+}
+
+var statLabels = map[string]string{
+	"hp":         "HP",
+	"attack":     "Atk",
+	"defense":    "Def",
+	"sp_attack":  "SpA",
+	"sp_defense": "SpD",
+	"speed":      "Spe",
+	"total":      "Total",
+}
+
+// displayName is the name in locale with the form, as pokemondb prints
+// it. Forms are only known in English.
+func displayName(p pokemon.Pokemon, locale string) string {
+	name := p.LocalName(locale)
+	if p.Form == "" {
+		return name
+	}
+	return name + " (" + p.Form + ")"
+}
+
+// formatNames lists names as "de Glurak, fr Dracaufeu, ...".
+func formatNames(names pokemon.Names) string {
+	locales := make([]string, 0, len(names))
+	for locale := range names {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	parts := make([]string, len(locales))
+	for i, locale := range locales {
+		parts[i] = locale + " " + names[locale]
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatYield(s pokemon.Stats) string {
+	var parts []string
+	for _, v := range []struct {
+		stat  string
+		value int
+	}{{"hp", s.HP}, {"attack", s.Attack}, {"defense", s.Defense}, {"sp_attack", s.SpAttack}, {"sp_defense", s.SpDefense}, {"speed", s.Speed}} {
+		if v.value > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", v.value, statLabels[v.stat]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pokemonRow is one line of table and CSV output.
+func pokemonRow(p pokemon.Pokemon, locale string) []string {
+	row := []string{p.Key, strconv.Itoa(p.Number), displayName(p, locale), strings.Join(p.Type, "/")}
+	for _, stat := range pokemon.StatNames {
+		v, _ := p.Stat(stat)
+		row = append(row, strconv.Itoa(v))
+	}
+	return append(row, strconv.Itoa(p.BaseExp))
+}
+
+func pokemonHeader() []string {
+	header := []string{"Key", "#", "Name", "Type"}
+	for _, stat := range pokemon.StatNames {
+		header = append(header, statLabels[stat])
+	}
+	return append(header, "Base exp")
+}
+
+func writePokemons(w io.Writer, format, locale string, pokemons []pokemon.Pokemon) error {
+	rows := make([][]string, len(pokemons))
+	for i, p := range pokemons {
+		rows[i] = pokemonRow(p, locale)
+	}
+	if pokemons == nil {
+		pokemons = []pokemon.Pokemon{}
+	}
+	return writeRows(w, format, pokemons, pokemonHeader(), rows)
+}
+
+// writeRows prints v as JSON, or header and rows as a table or CSV.
+func writeRows(w io.Writer, format string, v interface{}, header []string, rows [][]string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(header)
+		cw.WriteAll(rows)
+		return cw.Error()
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("unknown format %q, want table, json or csv", format)
+}
+
+// stringList is a flag that can be given more than once.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+var cliPokedex = []pokemon.Pokemon{
+	{
+		Key: "bulbasaur", Number: 1, Name: "Bulbasaur", Type: []string{"Grass", "Poison"}, BaseExp: 64,
+		Attributes: pokemon.Attributes{HP: 45, Attack: 49, Defense: 49, SpAttack: 65, SpDefense: 65, Speed: 45},
+	},
+	{
+		Key: "charmander", Number: 4, Name: "Charmander", Type: []string{"Fire"}, BaseExp: 62,
+		Attributes: pokemon.Attributes{HP: 39, Attack: 52, Defense: 43, SpAttack: 60, SpDefense: 50, Speed: 65},
+	},
+	{
+		Key: "pikachu", Number: 25, Name: "Pikachu", Type: []string{"Electric"}, BaseExp: 112,
+		Names:      pokemon.Names{"ja": "ピカチュウ"},
+		Attributes: pokemon.Attributes{HP: 35, Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50, Speed: 90},
+	},
+	{
+		Key: "raichu", Number: 26, Name: "Raichu", Type: []string{"Electric"}, BaseExp: 243,
+		Attributes: pokemon.Attributes{HP: 60, Attack: 90, Defense: 55, SpAttack: 90, SpDefense: 80, Speed: 110},
+	},
+	{
+		Key: "raichu-alolan", Number: 26, Name: "Raichu", Form: "Alolan Raichu", Type: []string{"Electric", "Psychic"}, BaseExp: 243,
+		Attributes: pokemon.Attributes{HP: 60, Attack: 85, Defense: 50, SpAttack: 95, SpDefense: 85, Speed: 110},
+	},
+}
+
+// inPokedexDir runs the rest of the test in a new directory holding
+// cliPokedex as pokedex.json and as the store database pokedex.db.
+func inPokedexDir(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	if err := savePokedex(pokemon.Pokedex{Pokemons: cliPokedex}); err != nil {
+		t.Fatal(err)
+	}
+	if err := importPokedex("pokedex.db", cliPokedex); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// run runs a command and returns what it printed.
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	err := commands[args[0]].run(args[1:], &buf)
+	return buf.String(), err
+}
+
+// csvKeys returns the first column of CSV output, the header left out.
+func csvKeys(t *testing.T, out string) []string {
+	t.Helper()
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("%v in\n%s", err, out)
+	}
+	var keys []string
+	for _, r := range records[1:] {
+		keys = append(keys, r[0])
+	}
+	return keys
+}
+
+func TestListCommands(t *testing.T) {
+	inPokedexDir(t)
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"filter"}, []string{"bulbasaur", "charmander", "pikachu", "raichu", "raichu-alolan"}},
+		{[]string{"filter", "-type", "electric"}, []string{"pikachu", "raichu", "raichu-alolan"}},
+		{[]string{"filter", "-type", "electric", "-type", "psychic"}, []string{"raichu-alolan"}},
+		{[]string{"filter", "-min", "speed=100"}, []string{"raichu", "raichu-alolan"}},
+		{[]string{"filter", "-min", "speed=60", "-max", "attack=55"}, []string{"charmander", "pikachu"}},
+		{[]string{"filter", "-max-exp", "100"}, []string{"bulbasaur", "charmander"}},
+		{[]string{"filter", "-number", "26"}, []string{"raichu", "raichu-alolan"}},
+		{[]string{"filter", "-name", "ピカチュウ", "-lang", "ja"}, []string{"pikachu"}},
+		{[]string{"filter", "-type", "dragon"}, nil},
+		{[]string{"search", "chu"}, []string{"pikachu", "raichu", "raichu-alolan"}},
+		{[]string{"search", "alolan"}, []string{"raichu-alolan"}},
+		{[]string{"search", "ピカ"}, []string{"pikachu"}},
+		{[]string{"sort", "-by", "speed", "-desc", "-limit", "3"}, []string{"raichu", "raichu-alolan", "pikachu"}},
+		{[]string{"sort", "-by", "base_exp"}, []string{"charmander", "bulbasaur", "pikachu", "raichu", "raichu-alolan"}},
+		{[]string{"top", "-stat", "attack", "-n", "2"}, []string{"raichu", "raichu-alolan"}},
+		{[]string{"top", "-stat", "sp_attack", "-n", "1", "-type", "electric"}, []string{"raichu-alolan"}},
+		{[]string{"show", "26"}, []string{"raichu", "raichu-alolan"}},
+		{[]string{"show", "charmander"}, []string{"charmander"}},
+		{[]string{"show", "-lang", "ja", "ピカチュウ"}, []string{"pikachu"}},
+	}
+	for _, source := range [][]string{nil, {"-db", "pokedex.db"}} {
+		for _, tt := range tests {
+			args := append([]string{tt.args[0], "-format", "csv"}, source...)
+			args = append(args, tt.args[1:]...)
+			out, err := run(t, args...)
+			if err != nil {
+				t.Errorf("%v: %v", args, err)
+				continue
+			}
+			if got := csvKeys(t, out); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%v = %v, want %v", args, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestShowTable(t *testing.T) {
+	inPokedexDir(t)
+	out, err := run(t, "show", "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"Name         Pikachu\n", "Other names  ja ピカチュウ\n", "Type         Electric\n", "Spe          90\n"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("show pikachu has no %q in\n%s", line, out)
+		}
+	}
+}
+
+func TestTypes(t *testing.T) {
+	inPokedexDir(t)
+	out, err := run(t, "types", "-format", "csv", "-db", "pokedex.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]string)
+	for _, r := range records[1:] {
+		counts[r[0]] = r[1]
+	}
+	want := map[string]string{"Electric": "3", "Fire": "1", "Grass": "1", "Poison": "1", "Psychic": "1"}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("type counts %v, want %v", counts, want)
+	}
+}
+
+func TestCommandErrors(t *testing.T) {
+	inPokedexDir(t)
+	for _, args := range [][]string{
+		{"filter", "-min", "speed"},
+		{"filter", "-min", "luck=3"},
+		{"filter", "-max", "speed=fast"},
+		{"filter", "-format", "xml"},
+		{"filter", "-db", "missing.db"},
+		{"filter", "-nosuchflag"},
+		{"search"},
+		{"sort"},
+		{"sort", "-by", "luck"},
+		{"top", "-stat", "luck"},
+		{"show"},
+		{"show", "mew"},
+		{"show", "-db", "pokedex.db", "mew"},
+		{"export"},
+		{"export", "pokedex.txt"},
+		{"import", "missing.json"},
+		{"names"},
+	} {
+		if out, err := run(t, args...); err == nil {
+			t.Errorf("%v succeeded with\n%s", args, out)
+		}
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	inPokedexDir(t)
+	want, err := pokemon.Load(pokedexFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{"json", "csv", "yaml", "proto"} {
+		name := filepath.Join(t.TempDir(), "pokedex."+format)
+		if _, err := run(t, "export", "-format", format, name); err != nil {
+			t.Fatalf("export %s: %v", format, err)
+		}
+		if err := os.Remove(pokedexFile); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := run(t, "import", "-format", format, name); err != nil {
+			t.Fatalf("import %s: %v", format, err)
+		}
+		got, err := pokemon.Load(pokedexFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s round trip:\n got %+v\nwant %+v", format, got, want)
+		}
+	}
+}
+
+func TestNames(t *testing.T) {
+	inPokedexDir(t)
+	names := `{"pikachu": {"vi": "Pikachu"}, "raichu": {"fr": "Raichu"}, "mew": {"fr": "Mew"}}`
+	if err := os.WriteFile("names.json", []byte(names), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := run(t, "names", "names.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "Added names to 3 entries of pokedex.json\n" {
+		t.Errorf("names printed %q", out)
+	}
+
+	pokemons, err := pokemon.Load(pokedexFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pokemons[2].Names; !reflect.DeepEqual(got, pokemon.Names{"ja": "ピカチュウ", "vi": "Pikachu"}) {
+		t.Errorf("pikachu names %v", got)
+	}
+	if got := pokemons[4].Names["fr"]; got != "Raichu" {
+		t.Errorf("alolan raichu has fr name %q, want the species'", got)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+	"github.com/thanhduy1706/PokeDBC/store"
+)
+
+// importPokedex replaces the contents of the store database at path with
+// pokemons.
+func importPokedex(path string, pokemons []pokemon.Pokemon) error {
+	db, err := store.Open(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Import(pokemons)
+}
+
+// finder looks entries up for the query commands and the API: a
+// *store.Store, or pokedexEntries when there is no database.
+type finder interface {
+	Find(q store.Query) ([]pokemon.Pokemon, error)
+	Get(key string) (pokemon.Pokemon, error)
+}
+
+// pokedexEntries scans the entries of pokedex.json.
+type pokedexEntries []pokemon.Pokemon
+
+func (e pokedexEntries) Find(q store.Query) ([]pokemon.Pokemon, error) {
+	var matches []pokemon.Pokemon
+	for _, p := range e {
+		if !q.Match(p) {
+			continue
+		}
+		matches = append(matches, p)
+		if q.Limit > 0 && len(matches) == q.Limit {
+			break
+		}
+	}
+	return matches, nil
+}
+
+func (e pokedexEntries) Get(key string) (pokemon.Pokemon, error) {
+	for _, p := range e {
+		if p.Key == key {
+			return p, nil
+		}
+	}
+	return pokemon.Pokemon{}, store.ErrNotFound
+}
+
+// openFinder opens the store database at path, or loads pokedex.json if
+// path is empty. Call done when finished with it.
+func openFinder(path string) (f finder, done func() error, err error) {
+	if path != "" {
+		db, err := store.OpenReadOnly(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, db.Close, nil
+	}
+	pokedex, err := loadPokedex()
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading pokedex: %w", err)
+	}
+	return pokedexEntries(pokedex.Pokemons), func() error { return nil }, nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// Be polite to pokemondb: a handful of requests in flight at most, and
+// never more than one new request per crawlDelay.
+const (
+	crawlConcurrency = 4
+	crawlDelay       = 500 * time.Millisecond
+)
+
+// detailPage holds what we read from a species' pokemondb page.
+type detailPage struct {
+	GrowthRate string
+	Names      pokemon.Names
+	Learnset   Learnset
+	Evolutions []pokemon.Evolution
+	Abilities  map[string][]pokemon.Ability // by entry key
+	Artwork    map[string]string            // image URL by entry key
+}
+
+func parseDetailPage(doc *goquery.Document) detailPage {
+	return detailPage{
+		GrowthRate: parseGrowthRate(doc),
+		Names:      parseNames(doc),
+		Learnset:   parseLearnset(doc),
+		Evolutions: parseEvolutions(doc),
+		Abilities:  parseAbilities(doc),
+		Artwork:    parseArtwork(doc),
+	}
+}
+
+// eachForm calls fn with the entry key and the tab panel of each form on a
+// detail page. Forms are the tabs of the first tab list, named like the
+// form column of the "all" table ("Mega Charizard X"), and each panel has
+// the form's own artwork, types and abilities.
+func eachForm(doc *goquery.Document, fn func(key string, panel *goquery.Selection)) {
+	species := strings.TrimSpace(doc.Find("h1").First().Text())
+	doc.Find("div.sv-tabs-tab-list").First().Find("a.sv-tabs-tab").Each(func(i int, tab *goquery.Selection) {
+		form := strings.TrimSpace(tab.Text())
+		if form == species {
+			form = ""
+		}
+		fn(pokemon.Key(species, form), doc.Find(tab.AttrOr("href", "")))
+	})
+}
+
+// applyDetails copies the per-species fields of the crawled pages onto
+// every form of the species.
+func applyDetails(pokemons []pokemon.Pokemon, pages map[string]detailPage) {
+	for i := range pokemons {
+		page, ok := pages[pokemons[i].SpeciesKey()]
+		if !ok {
+			continue
+		}
+		pokemons[i].GrowthRate = page.GrowthRate
+		pokemons[i].Names = page.Names
+		if abilities, ok := page.Abilities[pokemons[i].Key]; ok {
+			pokemons[i].Abilities = abilities
+		} else {
+			pokemons[i].Abilities = page.Abilities[pokemons[i].SpeciesKey()]
+		}
+	}
+}
+
+// restoreDetails gives entries scraped without their detail pages the
+// fields only those pages provide, as saved for them last time.
+func restoreDetails(pokemons, saved []pokemon.Pokemon) {
+	byKey := make(map[string]pokemon.Pokemon, len(saved))
+	for _, p := range saved {
+		byKey[p.Key] = p
+	}
+	for i := range pokemons {
+		if old, ok := byKey[pokemons[i].Key]; ok {
+			restoreDetail(&pokemons[i], old)
+		}
+	}
+}
+
+// restoreDetail gives p the growth rate and abilities of old if its
+// detail page wasn't crawled, and adds the names old has. Names may also
+// have been added with the names command.
+func restoreDetail(p *pokemon.Pokemon, old pokemon.Pokemon) {
+	if p.GrowthRate == "" {
+		p.GrowthRate = old.GrowthRate
+	}
+	if p.Abilities == nil {
+		p.Abilities = old.Abilities
+	}
+	p.Names = mergeNames(old.Names, p.Names)
+}
+
+// fetchDetails crawls the detail page of every species in pokemons. Forms
+// share their species' page, so each page is fetched once; the result is
+// keyed by species (see SpeciesKey). Pages that fail are logged and skipped.
+func (s *scraper) fetchDetails(ctx context.Context, pokemons []pokemon.Pokemon, sources []source) (map[string]detailPage, error) {
+	paths := make(map[string]string) // species key -> page path
+	var order []string
+	for i, p := range pokemons {
+		key := p.SpeciesKey()
+		path := sources[i].detailPath
+		if _, ok := paths[key]; ok || path == "" {
+			continue
+		}
+		paths[key] = path
+		order = append(order, key)
+	}
+
+	concurrency := s.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var tick <-chan time.Time
+	if s.delay > 0 {
+		ticker := time.NewTicker(s.delay)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		details = make(map[string]detailPage, len(order))
+		jobs    = make(chan string)
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				doc, err := s.fetchDocument(ctx, s.pokemondbURL+paths[key])
+				if err != nil {
+					log.Printf("Error fetching details for %s: %v", key, err)
+					continue
+				}
+				page := parseDetailPage(doc)
+				mu.Lock()
+				details[key] = page
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, key := range order {
+		if tick != nil {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		select {
+		case jobs <- key:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return details, ctx.Err()
+}
+
+// saveDetails fetches the move list and writes it, along with the files
+// built from the crawled detail pages, next to pokedex.json.
+func saveDetails(ctx context.Context, s *scraper, pokemons []pokemon.Pokemon, details map[string]detailPage) error {
+	moves, err := s.fetchMoves(ctx)
+	if err != nil {
+		return err
+	}
+	if err := saveJSON(movesFile, moves); err != nil {
+		return err
+	}
+
+	learnsets := make(map[string]Learnset, len(details))
+	for key, page := range details {
+		learnsets[key] = page.Learnset
+	}
+	if err := saveJSON(learnsetsFile, learnsets); err != nil {
+		return err
+	}
+
+	return saveJSON(evolutionsFile, mergeEvolutions(details, pokemons))
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+func TestRestoreDetails(t *testing.T) {
+	saved := []pokemon.Pokemon{
+		{Key: "pikachu", GrowthRate: pokemon.MediumFast, Names: pokemon.Names{"ja": "ピカチュウ"}, Abilities: []pokemon.Ability{{Name: "Static"}}},
+		{Key: "raichu", GrowthRate: pokemon.MediumFast},
+	}
+	pokemons := []pokemon.Pokemon{
+		{Key: "pikachu", Name: "Pikachu"},
+		{Key: "raichu", Name: "Raichu", GrowthRate: pokemon.Fast},
+		{Key: "meowth", Name: "Meowth"},
+	}
+	restoreDetails(pokemons, saved)
+
+	want := []pokemon.Pokemon{
+		{Key: "pikachu", Name: "Pikachu", GrowthRate: pokemon.MediumFast, Names: pokemon.Names{"ja": "ピカチュウ"}, Abilities: []pokemon.Ability{{Name: "Static"}}},
+		{Key: "raichu", Name: "Raichu", GrowthRate: pokemon.Fast},
+		{Key: "meowth", Name: "Meowth"},
+	}
+	if !reflect.DeepEqual(pokemons, want) {
+		t.Errorf("got  %+v\nwant %+v", pokemons, want)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+const evolutionsFile = "evolutions.json"
+
+// parseEvolutions reads every chart on a detail page. Forms with their own
+// line (Alolan Meowth -> Alolan Persian) get a chart of their own, and
+// branches (Pikachu -> Raichu or Alolan Raichu) nest a list per branch.
+func parseEvolutions(doc *goquery.Document) []pokemon.Evolution {
+	var evolutions []pokemon.Evolution
+	doc.Find("div.infocard-list-evo").Each(func(i int, list *goquery.Selection) {
+		// Nested lists are walked from their parent's split
+		if list.ParentsFiltered("span.infocard-evo-split").Length() > 0 {
+			return
+		}
+		evolutions = append(evolutions, parseEvoList(list, "")...)
+	})
+	return evolutions
+}
+
+// parseEvoList walks one row of cards and arrows. from is the card the
+// row continues from, empty at the start of a chart.
+func parseEvoList(list *goquery.Selection, from string) []pokemon.Evolution {
+	var evolutions []pokemon.Evolution
+	var arrow string
+	list.Children().Each(func(i int, child *goquery.Selection) {
+		switch {
+		case child.HasClass("infocard-arrow"):
+			arrow = strings.Trim(strings.TrimSpace(child.Find("small").Text()), "()")
+		case child.HasClass("infocard-evo-split"):
+			child.ChildrenFiltered("div.infocard-list-evo").Each(func(j int, branch *goquery.Selection) {
+				evolutions = append(evolutions, parseEvoList(branch, from)...)
+			})
+		case child.HasClass("infocard"):
+			to := infocardKey(child)
+			if from != "" {
+				evolutions = append(evolutions, newEvolution(from, to, arrow))
+			}
+			from, arrow = to, ""
+		}
+	})
+	return evolutions
+}
+
+// infocardKey builds the entry key from a card's name and, for regional
+// forms, the form label printed under it.
+func infocardKey(card *goquery.Selection) string {
+	data := card.Find("span.infocard-lg-data")
+	name := strings.TrimSpace(data.Find("a.ent-name").Text())
+	var form string
+	data.ChildrenFiltered("small").Each(func(i int, small *goquery.Selection) {
+		text := strings.TrimSpace(small.Text())
+		if small.Find("a").Length() == 0 && !strings.HasPrefix(text, "#") {
+			form = text
+		}
+	})
+	return pokemon.Key(name, form)
+}
+
+var levelCondition = regexp.MustCompile(`^Level (\d+),?\s*`)
+
+// newEvolution splits an arrow label such as "Level 16", "use Thunder
+// Stone" or "high Friendship" into the trigger fields.
+func newEvolution(from, to, label string) pokemon.Evolution {
+	e := pokemon.Evolution{From: from, To: to}
+	if m := levelCondition.FindStringSubmatch(label); m != nil {
+		e.Level, _ = strconv.Atoi(m[1])
+		label = label[len(m[0]):]
+	}
+	if item, ok := strings.CutPrefix(label, "use "); ok {
+		item, rest, _ := strings.Cut(item, ",")
+		e.Item = strings.TrimSpace(item)
+		label = strings.TrimSpace(rest)
+	}
+	e.Condition = label
+	return e
+}
+
+// mergeEvolutions drops the copies of a chart that every member's page
+// repeats and orders the result by the dex number of From.
+func mergeEvolutions(pages map[string]detailPage, pokemons []pokemon.Pokemon) []pokemon.Evolution {
+	numbers := make(map[string]int, len(pokemons))
+	for _, p := range pokemons {
+		numbers[p.Key] = p.Number
+	}
+
+	seen := make(map[string]bool)
+	var evolutions []pokemon.Evolution
+	for _, page := range pages {
+		for _, e := range page.Evolutions {
+			if seen[e.From+">"+e.To] {
+				continue
+			}
+			seen[e.From+">"+e.To] = true
+			evolutions = append(evolutions, e)
+		}
+	}
+
+	sort.Slice(evolutions, func(i, j int) bool {
+		a, b := evolutions[i], evolutions[j]
+		if numbers[a.From] != numbers[b.From] {
+			return numbers[a.From] < numbers[b.From]
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	return evolutions
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// exportFormats are the formats export writes and import reads.
+var exportFormats = map[string]struct {
+	write func(w io.Writer, pokemons []pokemon.Pokemon) error
+	read  func(r io.Reader) ([]pokemon.Pokemon, error)
+}{
+	"json":  {writePokedexJSON, pokemon.Decode},
+	"csv":   {pokemon.WriteCSV, pokemon.ReadCSV},
+	"yaml":  {pokemon.WriteYAML, pokemon.ReadYAML},
+	"proto": {pokemon.WriteProto, pokemon.ReadProto},
+}
+
+// formatExtensions pick a format when -format isn't given.
+var formatExtensions = map[string]string{
+	".json":  "json",
+	".csv":   "csv",
+	".yaml":  "yaml",
+	".yml":   "yaml",
+	".pb":    "proto",
+	".binpb": "proto",
+}
+
+// writePokedexJSON writes the same indented JSON as savePokedex.
+func writePokedexJSON(w io.Writer, pokemons []pokemon.Pokemon) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(pokemons)
+}
+
+// fileFormat returns the format named by flag, or failing that by the
+// extension of name.
+func fileFormat(flag, name string) (string, error) {
+	format := flag
+	if format == "" {
+		format = formatExtensions[strings.ToLower(filepath.Ext(name))]
+	}
+	if _, ok := exportFormats[format]; !ok {
+		return "", fmt.Errorf("unknown format for %q, use -format json, csv, yaml or proto", name)
+	}
+	return format, nil
+}
+
+func runExport(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "", "json, csv, yaml or proto; by default from the file extension")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("export: want one file name, or - for standard output")
+	}
+	name := fs.Arg(0)
+	f, err := fileFormat(*format, name)
+	if err != nil {
+		return err
+	}
+
+	pokedex, err := loadPokedex()
+	if err != nil {
+		return fmt.Errorf("loading pokedex: %w", err)
+	}
+	if name == "-" {
+		return exportFormats[f].write(w, pokedex.Pokemons)
+	}
+
+	out, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := exportFormats[f].write(out, pokedex.Pokemons); err != nil {
+		out.Close()
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Exported %d entries to %s\n", len(pokedex.Pokemons), name)
+	return nil
+}
+
+// runImport replaces pokedex.json with the entries of a file written by
+// export, or by a spreadsheet with the same columns.
+func runImport(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "", "json, csv, yaml or proto; by default from the file extension")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("import: want one file name")
+	}
+	name := fs.Arg(0)
+	f, err := fileFormat(*format, name)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	pokemons, err := exportFormats[f].read(in)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+
+	if err := savePokedex(pokemon.Pokedex{Pokemons: pokemons}); err != nil {
+		return fmt.Errorf("saving pokedex: %w", err)
+	}
+	fmt.Fprintf(w, "Imported %d entries from %s into %s\n", len(pokemons), name, pokedexFile)
+	return nil
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	fetchTimeout  = 30 * time.Second
+	fetchRetries  = 3
+	fetchBackoff  = time.Second
+	fetchMaxWait  = time.Minute // longest Retry-After honored
+	fetchCacheDir = ".pokedex-cache"
+)
+
+// fetcher is the HTTP layer shared by every page the scraper reads. It
+// gives each request a timeout, retries 429 and 5xx responses with
+// exponential backoff, and keeps responses in an on-disk cache that is
+// revalidated with ETag / Last-Modified instead of downloaded again.
+type fetcher struct {
+	client   *http.Client
+	retries  int           // extra attempts after the first
+	backoff  time.Duration // wait before the first retry, doubled after each
+	maxWait  time.Duration // cap on the waits a Retry-After header asks for
+	cacheDir string        // "" disables the cache
+}
+
+func newFetcher(client *http.Client) *fetcher {
+	return &fetcher{
+		client:  client,
+		retries: fetchRetries,
+		backoff: fetchBackoff,
+		maxWait: fetchMaxWait,
+	}
+}
+
+// cacheEntry is the metadata stored next to a cached body.
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// statusError is returned for responses other than 200 and 304.
+type statusError struct {
+	url  string
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s: status code: %d %s", e.url, e.code, http.StatusText(e.code))
+}
+
+func retryable(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// transient reports whether a failed attempt may succeed later: a network
+// error, or a 429 or 5xx response.
+func transient(ctx context.Context, err error) bool {
+	var se *statusError
+	if errors.As(err, &se) {
+		return retryable(se.code)
+	}
+	return ctx.Err() == nil
+}
+
+// get returns the body of url, from the cache if the server says it has
+// not changed. If every attempt fails for a reason that may go away (see
+// transient) but a cached copy exists, the cached copy is returned and the
+// failure logged.
+func (f *fetcher) get(ctx context.Context, url string) ([]byte, error) {
+	entry, body := f.readCache(url)
+
+	wait := f.backoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		var res *http.Response
+		res, err = f.do(ctx, url, entry)
+		if err == nil {
+			var fresh []byte
+			fresh, err = f.handle(url, res, entry, body)
+			if err == nil {
+				return fresh, nil
+			}
+		}
+
+		if !transient(ctx, err) || attempt >= f.retries {
+			break
+		}
+
+		delay := wait
+		if res != nil {
+			if after, convErr := strconv.Atoi(res.Header.Get("Retry-After")); convErr == nil && after >= 0 {
+				delay = time.Duration(after) * time.Second
+				if delay > f.maxWait {
+					delay = f.maxWait
+				}
+			}
+		}
+		log.Printf("Fetching %s failed (%v), retrying in %s", url, err, delay)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		wait *= 2
+	}
+
+	if entry != nil && body != nil && transient(ctx, err) {
+		log.Printf("Fetching %s failed (%v), using cached copy from %s", url, err, entry.FetchedAt.Format(time.RFC3339))
+		return body, nil
+	}
+	return nil, err
+}
+
+func (f *fetcher) do(ctx context.Context, url string, entry *cacheEntry) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if entry != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+	return f.client.Do(req)
+}
+
+// handle reads a response, serving 304s from the cache and storing 200s.
+func (f *fetcher) handle(url string, res *http.Response, entry *cacheEntry, cached []byte) ([]byte, error) {
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotModified && entry != nil && cached != nil:
+		revalidated := *entry
+		revalidated.FetchedAt = time.Now()
+		if etag := res.Header.Get("ETag"); etag != "" {
+			revalidated.ETag = etag
+		}
+		f.writeCache(revalidated, nil)
+		return cached, nil
+	case res.StatusCode != http.StatusOK:
+		return nil, &statusError{url: url, code: res.StatusCode}
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	f.writeCache(cacheEntry{
+		URL:          url,
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+	}, body)
+	return body, nil
+}
+
+// cachePath names the files of url in the cache directory: the body, which
+// may be a page or an image, with a ".body" suffix and its cacheEntry with
+// ".json".
+func (f *fetcher) cachePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(f.cacheDir, hex.EncodeToString(sum[:]))
+}
+
+func (f *fetcher) readCache(url string) (*cacheEntry, []byte) {
+	if f.cacheDir == "" {
+		return nil, nil
+	}
+	path := f.cachePath(url)
+
+	meta, err := os.ReadFile(path + ".json")
+	if err != nil {
+		return nil, nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(meta, &entry); err != nil || entry.URL != url {
+		return nil, nil
+	}
+	body, err := os.ReadFile(path + ".body")
+	if err != nil {
+		return nil, nil
+	}
+	return &entry, body
+}
+
+// writeCache stores a response, or only its entry if body is nil. Failing
+// to cache is not worth failing the scrape for, so errors are only logged.
+func (f *fetcher) writeCache(entry cacheEntry, body []byte) {
+	if f.cacheDir == "" {
+		return
+	}
+	if err := os.MkdirAll(f.cacheDir, 0o755); err != nil {
+		log.Printf("Error creating cache directory: %v", err)
+		return
+	}
+
+	path := f.cachePath(entry.URL)
+	meta, err := json.Marshal(entry)
+	if err == nil && body != nil {
+		err = os.WriteFile(path+".body", body, 0o644)
+	}
+	if err == nil {
+		err = os.WriteFile(path+".json", meta, 0o644)
+	}
+	if err != nil {
+		log.Printf("Error caching %s: %v", entry.URL, err)
+	}
+}
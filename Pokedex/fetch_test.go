@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// cachedServer serves "fresh" with an ETag on the first request, then
+// answers with the statuses in order, repeating the last one.
+func cachedServer(t *testing.T, statuses ...int) *httptest.Server {
+	t.Helper()
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte("fresh"))
+			return
+		}
+		status := statuses[len(statuses)-1]
+		if requests-2 < len(statuses) {
+			status = statuses[requests-2]
+		}
+		if status == http.StatusNotModified && r.Header.Get("If-None-Match") != `"v1"` {
+			t.Errorf("request %d revalidates with If-None-Match %q", requests, r.Header.Get("If-None-Match"))
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// cachingFetcher fetches url once to fill a fresh cache.
+func cachingFetcher(t *testing.T, url string) *fetcher {
+	t.Helper()
+	f := newFetcher(http.DefaultClient)
+	f.backoff = 0
+	f.cacheDir = t.TempDir()
+	if body, err := f.get(context.Background(), url); err != nil || string(body) != "fresh" {
+		t.Fatalf("first fetch = %q, %v", body, err)
+	}
+	return f
+}
+
+func TestFetchUsesCacheWhenServerFails(t *testing.T) {
+	for _, status := range []int{http.StatusServiceUnavailable, http.StatusTooManyRequests} {
+		srv := cachedServer(t, status)
+		f := cachingFetcher(t, srv.URL)
+		body, err := f.get(context.Background(), srv.URL)
+		if err != nil || string(body) != "fresh" {
+			t.Errorf("status %d: got %q, %v, want the cached copy", status, body, err)
+		}
+	}
+}
+
+func TestFetchRetriesIntoNotModified(t *testing.T) {
+	srv := cachedServer(t, http.StatusServiceUnavailable, http.StatusNotModified)
+	f := cachingFetcher(t, srv.URL)
+	body, err := f.get(context.Background(), srv.URL)
+	if err != nil || string(body) != "fresh" {
+		t.Errorf("got %q, %v, want the cached copy", body, err)
+	}
+}
+
+func TestFetchWithoutCacheFails(t *testing.T) {
+	srv := cachedServer(t, http.StatusServiceUnavailable)
+	f := newFetcher(http.DefaultClient)
+	f.backoff = 0
+	if _, err := f.get(context.Background(), srv.URL); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if body, err := f.get(context.Background(), srv.URL); err == nil {
+		t.Errorf("got %q, want an error", body)
+	}
+}
+
+func TestFetchFailsOnClientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusForbidden} {
+		srv := cachedServer(t, status)
+		f := cachingFetcher(t, srv.URL)
+		if body, err := f.get(context.Background(), srv.URL); err == nil {
+			t.Errorf("status %d: got %q, want an error instead of the cached copy", status, body)
+		}
+	}
+}
+
+func TestFetchCapsRetryAfter(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests++; requests == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("fresh"))
+	}))
+	defer srv.Close()
+
+	f := newFetcher(http.DefaultClient)
+	f.maxWait = 10 * time.Millisecond
+	start := time.Now()
+	body, err := f.get(context.Background(), srv.URL)
+	if err != nil || string(body) != "fresh" {
+		t.Fatalf("got %q, %v", body, err)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("waited %s for a Retry-After of an hour, want at most %s", waited, f.maxWait)
+	}
+}
+
+func TestFetchNotModifiedRefreshesEntry(t *testing.T) {
+	srv := cachedServer(t, http.StatusNotModified)
+	f := cachingFetcher(t, srv.URL)
+	entry, body := f.readCache(srv.URL)
+	if _, err := os.Stat(f.cachePath(srv.URL) + ".body"); err != nil {
+		t.Errorf("cached body: %v", err)
+	}
+
+	stale := *entry
+	stale.FetchedAt = time.Now().Add(-24 * time.Hour)
+	f.writeCache(stale, nil)
+	if _, err := f.get(context.Background(), srv.URL); err != nil {
+		t.Fatalf("revalidating: %v", err)
+	}
+
+	entry, cached := f.readCache(srv.URL)
+	if time.Since(entry.FetchedAt) > time.Hour {
+		t.Errorf("fetched_at = %s after a 304, want now", entry.FetchedAt)
+	}
+	if string(cached) != string(body) {
+		t.Errorf("cached body = %q after a 304, want %q", cached, body)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// parseGrowthRate reads the "Growth Rate" row of the Training table as one
+// of the pokemon package's growth rates, e.g. "medium-slow".
+func parseGrowthRate(doc *goquery.Document) string {
+	var rate string
+	doc.Find("table.vitals-table th").EachWithBreak(func(i int, th *goquery.Selection) bool {
+		if strings.TrimSpace(th.Text()) != "Growth Rate" {
+			return true
+		}
+		rate = strings.TrimSpace(th.Next().Text())
+		return false
+	})
+	return strings.ReplaceAll(strings.ToLower(rate), " ", "-")
+}
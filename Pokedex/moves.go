@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+const (
+	movesPath      = "/move/all"
+	movesFile      = "moves.json"
+	learnsetsFile  = "learnsets.json"
+	levelUpHeading = "Moves learnt by level up"
+)
+
+// Learnset maps a level to the moves learnt on reaching it. Level 0 holds
+// the moves learnt on evolution.
+type Learnset map[int][]string
+
+func (s *scraper) fetchMoves(ctx context.Context) ([]pokemon.Move, error) {
+	doc, err := s.fetchDocument(ctx, s.pokemondbURL+movesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var moves []pokemon.Move
+	doc.Find("table#moves tbody tr").Each(func(i int, s *goquery.Selection) {
+		cells := s.Find("td")
+		category, ok := cells.Eq(2).Attr("data-sort-value")
+		if !ok {
+			category = cells.Eq(2).Find("img").AttrOr("title", "")
+		}
+
+		name := strings.TrimSpace(cells.Eq(0).Find("a.ent-name").Text())
+		effect := strings.TrimSpace(cells.Eq(6).Text())
+		moves = append(moves, pokemon.Move{
+			Name:     name,
+			Type:     strings.TrimSpace(cells.Eq(1).Text()),
+			Category: strings.ToLower(strings.TrimSpace(category)),
+			Power:    parseIntOrDefault(cells.Eq(3).Text(), 0),
+			Accuracy: parseIntOrDefault(cells.Eq(4).Text(), 0),
+			PP:       parseIntOrDefault(cells.Eq(5).Text(), 0),
+			Priority: movePriority(name, effect),
+			Effect:   effect,
+		})
+	})
+
+	return moves, nil
+}
+
+// movePriorities are the priorities of the moves whose effect on the move
+// list doesn't give it, or only says they attack first: their bracket in
+// the main games since generation V.
+var movePriorities = map[string]int{
+	"Helping Hand":     5,
+	"Protect":          4,
+	"Detect":           4,
+	"Endure":           4,
+	"Magic Coat":       4,
+	"Snatch":           4,
+	"King's Shield":    4,
+	"Spiky Shield":     4,
+	"Baneful Bunker":   4,
+	"Obstruct":         4,
+	"Silk Trap":        4,
+	"Burning Bulwark":  4,
+	"Fake Out":         3,
+	"Quick Guard":      3,
+	"Wide Guard":       3,
+	"Crafty Shield":    3,
+	"Upper Hand":       3,
+	"Extreme Speed":    2,
+	"Feint":            2,
+	"First Impression": 2,
+	"Follow Me":        2,
+	"Rage Powder":      2,
+	"Ally Switch":      2,
+	"Zippy Zap":        2,
+	"Focus Punch":      -3,
+	"Beak Blast":       -3,
+	"Shell Trap":       -3,
+	"Avalanche":        -4,
+	"Revenge":          -4,
+	"Counter":          -5,
+	"Mirror Coat":      -5,
+	"Roar":             -6,
+	"Whirlwind":        -6,
+	"Dragon Tail":      -6,
+	"Circle Throw":     -6,
+	"Teleport":         -6,
+	"Trick Room":       -7,
+}
+
+// movePriority is a move's priority: from movePriorities, else +1 for the
+// moves whose effect reads "User attacks first." (Quick Attack, Aqua
+// Jet, ...) and -1 for "User attacks last." (Vital Throw).
+func movePriority(name, effect string) int {
+	if priority, ok := movePriorities[name]; ok {
+		return priority
+	}
+	effect = strings.ToLower(effect)
+	switch {
+	case strings.HasPrefix(effect, "user attacks first"):
+		return 1
+	case strings.HasPrefix(effect, "user attacks last"):
+		return -1
+	}
+	return 0
+}
+
+// parseLearnset reads the level-up table of the first game tab on a
+// species' detail page.
+func parseLearnset(doc *goquery.Document) Learnset {
+	learnset := make(Learnset)
+	heading := doc.Find("h3").FilterFunction(func(i int, s *goquery.Selection) bool {
+		return strings.TrimSpace(s.Text()) == levelUpHeading
+	}).First()
+
+	table := heading.NextAllFiltered("div.resp-scroll").First()
+	table.Find("tbody tr").Each(func(i int, s *goquery.Selection) {
+		level := parseIntOrDefault(s.Find("td.cell-num").First().Text(), 0)
+		move := strings.TrimSpace(s.Find("td.cell-name a.ent-name").Text())
+		if move != "" {
+			learnset[level] = append(learnset[level], move)
+		}
+	})
+
+	return learnset
+}
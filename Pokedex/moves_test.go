@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMovePriority(t *testing.T) {
+	tests := []struct {
+		name, effect string
+		want         int
+	}{
+		{"Quick Attack", "User attacks first.", 1},
+		{"Sucker Punch", "User attacks first, but only works if opponent is readying an attack.", 1},
+		{"Vital Throw", "User attacks last, but ignores Accuracy and Evasiveness.", -1},
+		{"Extreme Speed", "User attacks first.", 2},
+		{"Fake Out", "User attacks first, foe flinches. Only usable on first turn.", 3},
+		{"Protect", "Protects the user, but may fail if used consecutively.", 4},
+		{"Trick Room", "Slower Pokémon move first in the turn for 5 turns.", -7},
+		{"Avalanche", "Power doubles if user took damage first.", -4},
+		{"Solar Beam", "Charges on first turn, attacks on second.", 0},
+		{"Tackle", "", 0},
+	}
+	for _, tt := range tests {
+		if got := movePriority(tt.name, tt.effect); got != tt.want {
+			t.Errorf("movePriority(%q, %q) = %d, want %d", tt.name, tt.effect, got, tt.want)
+		}
+	}
+}
+
+func TestFetchMoves(t *testing.T) {
+	moves, err := newFixtureScraper("testdata").fetchMoves(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"Quick Attack": 1, "Fake Out": 3, "Feint": 2, "Solar Beam": 0, "Tackle": 0}
+	for _, m := range moves {
+		if priority, ok := want[m.Name]; ok {
+			if m.Priority != priority {
+				t.Errorf("%s has priority %d, want %d", m.Name, m.Priority, priority)
+			}
+			delete(want, m.Name)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("no %v in the move list", want)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// nameLocales maps the languages of pokemondb's "Other languages" table
+// to the locales of pokemon.Names. English is the entry's Name.
+var nameLocales = map[string]string{
+	"Japanese":              "ja",
+	"German":                "de",
+	"French":                "fr",
+	"Italian":               "it",
+	"Spanish":               "es",
+	"Korean":                "ko",
+	"Chinese (Simplified)":  "zh-Hans",
+	"Chinese (Traditional)": "zh-Hant",
+}
+
+// parseNames reads the "Other languages" table of a detail page. Names in
+// other scripts are followed by a romanization, "ピカチュウ (Pikachu)",
+// which is dropped.
+func parseNames(doc *goquery.Document) pokemon.Names {
+	names := make(pokemon.Names)
+	doc.Find("h2").Each(func(i int, h2 *goquery.Selection) {
+		if strings.TrimSpace(h2.Text()) != "Other languages" {
+			return
+		}
+		h2.Next().Find("table.vitals-table tr").Each(func(j int, tr *goquery.Selection) {
+			locale, ok := nameLocales[strings.TrimSpace(tr.Find("th").Text())]
+			if !ok {
+				return
+			}
+			name, _, _ := strings.Cut(strings.TrimSpace(tr.Find("td").Text()), " (")
+			if name != "" {
+				names[locale] = name
+			}
+		})
+	})
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+// speciesByName maps every name of every species, slugified, to its
+// English name, so that a source spelling a name its own way still finds
+// the entry.
+func speciesByName(pokemons []pokemon.Pokemon) map[string]string {
+	species := make(map[string]string)
+	for _, p := range pokemons {
+		for _, name := range p.AllNames() {
+			if slug := pokemon.Slugify(name); slug != "" {
+				if _, ok := species[slug]; !ok {
+					species[slug] = p.Name
+				}
+			}
+		}
+	}
+	return species
+}
+
+// runNames merges names from a JSON file of key -> locale -> name into
+// pokedex.json, for languages pokemondb doesn't list, e.g.
+//
+//	{"pikachu": {"vi": "Pikachu"}, "meowth-alolan": {"vi": "Meowth Alola"}}
+//
+// Names given for a species key apply to its forms too.
+func runNames(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("names", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("names: want one file")
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var byKey map[string]pokemon.Names
+	if err := json.Unmarshal(data, &byKey); err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	pokedex, err := loadPokedex()
+	if err != nil {
+		return fmt.Errorf("loading pokedex: %w", err)
+	}
+	updated := 0
+	for i := range pokedex.Pokemons {
+		p := &pokedex.Pokemons[i]
+		names, ok := byKey[p.Key]
+		if !ok {
+			names, ok = byKey[p.SpeciesKey()]
+		}
+		if !ok {
+			continue
+		}
+		if p.Names == nil {
+			p.Names = make(pokemon.Names)
+		}
+		for locale, name := range names {
+			p.Names[locale] = name
+		}
+		updated++
+	}
+	if err := savePokedex(pokedex); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Added names to %d entries of %s\n", updated, pokedexFile)
+	return nil
+}
@@ -2,137 +2,200 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/thanhduy1706/PokeDBC/assets"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
 )
 
 // Add constants for better maintainability
 const (
-	pokedexURL = "https://pokemondb.net/pokedex/all"
-	baseExpURL = "https://bulbapedia.bulbagarden.net/wiki/List_of_Pokémon_by_effort_value_yield_(Generation_IX)"
-	pokedexFile = "pokedex.json"
+	pokemondbURL  = "https://pokemondb.net"
+	bulbapediaURL = "https://bulbapedia.bulbagarden.net"
+	pokedexPath   = "/pokedex/all"
+	baseExpPath   = "/wiki/List_of_Pokémon_by_effort_value_yield_(Generation_IX)"
+	pokedexFile   = "pokedex.json"
 )
 
-type Attributes struct {
-	HP           int `json:"hp"`
-	Attack       int `json:"attack"`
-	Defense      int `json:"defense"`
-	Speed        int `json:"speed"`
-	SpAttack     int `json:"sp_attack"`
-	SpDefense    int `json:"sp_defense"`
-	DmgWhenAtked int `json:"dmg_when_atked"`
+// source is where on pokemondb a scraped entry came from.
+type source struct {
+	detailPath string // pokemondb page of the species, e.g. /pokedex/bulbasaur
+	row        string // HTML of the pokemondb row, for the validation report
 }
 
-type Pokemon struct {
-	Name       string     `json:"name"`
-	Type       []string   `json:"type"`
-	BaseExp    int        `json:"base_exp"`
-	Experience int        `json:"exp"`
-	Level      int        `json:"level"`
-	EV         float64    `json:"ev"`
-	Attributes Attributes `json:"attributes"`
+// scraper downloads the source pages. Swap the fetcher's client and the
+// base URLs to scrape saved fixtures or an httptest server instead of the
+// live sites.
+type scraper struct {
+	fetch         *fetcher
+	pokemondbURL  string
+	bulbapediaURL string
+	concurrency   int           // detail pages fetched at once
+	delay         time.Duration // minimum gap between detail page requests
 }
 
-type Pokedex struct {
-	Pokemons []Pokemon `json:"pokemons"`
+func newScraper() *scraper {
+	return &scraper{
+		fetch:         newFetcher(&http.Client{Timeout: fetchTimeout}),
+		pokemondbURL:  pokemondbURL,
+		bulbapediaURL: bulbapediaURL,
+		concurrency:   crawlConcurrency,
+		delay:         crawlDelay,
+	}
 }
 
-// Use context for HTTP requests
-func fetchPokemonData(ctx context.Context) ([]Pokemon, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pokedexURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+// newFixtureScraper reads pages from dir instead of the network. The
+// directory mirrors the live sites as host/path, e.g.
+// dir/pokemondb.net/pokedex/all (the layout wget --force-directories saves).
+func newFixtureScraper(dir string) *scraper {
+	return &scraper{
+		fetch:         newFetcher(&http.Client{Transport: fixtureTransport{http.NewFileTransport(http.Dir(dir))}}),
+		pokemondbURL:  "file:///pokemondb.net",
+		bulbapediaURL: "file:///bulbapedia.bulbagarden.net",
+		concurrency:   crawlConcurrency,
 	}
+}
 
-	client := &http.Client{}
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
+// fixtureTransport serves absolute URLs found in the pages, such as the
+// artwork on img.pokemondb.net, from the host's directory of the fixtures.
+type fixtureTransport struct {
+	files http.RoundTripper
+}
 
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("status code: %d %s", res.StatusCode, res.Status)
+func (t fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != "" {
+		req = req.Clone(req.Context())
+		req.URL.Path = "/" + req.URL.Host + req.URL.Path
+		req.URL.Host = ""
 	}
+	return t.files.RoundTrip(req)
+}
 
-	doc, err := goquery.NewDocumentFromReader(res.Body)
+// Use context for HTTP requests
+func (s *scraper) fetchDocument(ctx context.Context, url string) (*goquery.Document, error) {
+	body, err := s.fetch.get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
+	return goquery.NewDocumentFromReader(bytes.NewReader(body))
+}
+
+// fetchPokemonData reads the pokemondb "all" table, one entry per row in
+// pokedex order. Rows may repeat a key, see uniquePokedex. Along with the
+// entries it returns where each one came from, by index.
+func (s *scraper) fetchPokemonData(ctx context.Context) ([]pokemon.Pokemon, []source, error) {
+	doc, err := s.fetchDocument(ctx, s.pokemondbURL+pokedexPath)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	var pokemons []Pokemon
+	var pokemons []pokemon.Pokemon
+	var sources []source
 	// Find each Pokemon row in the table
 	doc.Find("table.data-table tbody tr").Each(func(i int, s *goquery.Selection) {
-		// Initialize a Pokemon struct
-		pokemon := Pokemon{}
-
-		// Find and parse Pokemon name
-		pokemon.Name = s.Find("td.cell-name a.ent-name").Text()
-
-		// Find and parse Pokemon types
-		typeSelection := s.Find("td.cell-icon a")
-		typeSelection.Each(func(j int, typeLink *goquery.Selection) {
-			pokemon.Type = append(pokemon.Type, strings.TrimSpace(typeLink.Text()))
-		})
-
-		// Initialize Attributes
-		pokemon.BaseExp = 0
-		pokemon.Experience = 0
-		pokemon.Level = 1
-		pokemon.EV = 0.5
-
-		// Find and parse Pokemon attributes
-		s.Find("td.cell-num").Each(func(k int, attrSelection *goquery.Selection) {
-			attrValue := parseIntOrDefault(attrSelection.Text(), 0)
-			// Assign attribute value to the corresponding field
-			switch k {
-			case 2:
-				pokemon.Attributes.HP = attrValue
-			case 3:
-				pokemon.Attributes.Attack = attrValue
-			case 4:
-				pokemon.Attributes.Defense = attrValue
-			case 5:
-				pokemon.Attributes.SpAttack = attrValue
-			case 6:
-				pokemon.Attributes.SpDefense = attrValue
-			case 7:
-				pokemon.Attributes.Speed = attrValue
-			}
-		})
+		p, src := parsePokemonRow(s)
+		pokemons = append(pokemons, p)
+		sources = append(sources, src)
+	})
+
+	sort.Stable(byNumber{pokemons, sources})
+	return pokemons, sources, nil
+}
 
-		// Append Pokemon data to the slice
-		pokemons = append(pokemons, pokemon)
+// parsePokemonRow reads one row of the pokemondb "all" table.
+func parsePokemonRow(s *goquery.Selection) (pokemon.Pokemon, source) {
+	p := pokemon.Pokemon{Level: 1}
+	var src source
+	src.row, _ = goquery.OuterHtml(s)
+
+	// Find and parse Pokemon name. Alternate forms (Mega, Alolan, ...)
+	// repeat the species name and put the form underneath it.
+	name := s.Find("td.cell-name a.ent-name")
+	p.Name = strings.TrimSpace(name.Text())
+	src.detailPath = name.AttrOr("href", "")
+	p.Form = strings.TrimSpace(s.Find("td.cell-name small").Text())
+	p.Key = pokemon.Key(p.Name, p.Form)
+
+	// Find and parse Pokemon types
+	s.Find("td.cell-icon a").Each(func(j int, typeLink *goquery.Selection) {
+		p.Type = append(p.Type, strings.TrimSpace(typeLink.Text()))
 	})
 
-	return pokemons, nil
+	parseAttributes(&p, s)
+	return p, src
+}
+
+// byNumber orders rows by National Dex number, moving their sources along.
+// Sort it stably to keep the site's order between forms of the same
+// species.
+type byNumber struct {
+	pokemons []pokemon.Pokemon
+	sources  []source
+}
+
+func (b byNumber) Len() int           { return len(b.pokemons) }
+func (b byNumber) Less(i, j int) bool { return b.pokemons[i].Number < b.pokemons[j].Number }
+func (b byNumber) Swap(i, j int) {
+	b.pokemons[i], b.pokemons[j] = b.pokemons[j], b.pokemons[i]
+	b.sources[i], b.sources[j] = b.sources[j], b.sources[i]
+}
+
+// uniquePokedex drops the entries that repeat an earlier entry's key. Run
+// it after validatePokedex, which reports them.
+func uniquePokedex(pokemons []pokemon.Pokemon) []pokemon.Pokemon {
+	seen := make(map[string]bool)
+	unique := pokemons[:0]
+	for _, p := range pokemons {
+		if seen[p.Key] {
+			continue
+		}
+		seen[p.Key] = true
+		unique = append(unique, p)
+	}
+	return unique
 }
 
-func fetchBaseExp(pokemons []Pokemon) error {
+func (s *scraper) fetchBaseExp(ctx context.Context, pokemons []pokemon.Pokemon) error {
 	// Fetch the HTML page
-	doc, err := goquery.NewDocument(baseExpURL)
+	doc, err := s.fetchDocument(ctx, s.bulbapediaURL+baseExpPath)
 	if err != nil {
 		return fmt.Errorf("failed to fetch HTML: %v", err)
 	}
 
-	// Map to store BaseExp values by Pokemon name for quick lookup
+	// Maps to store BaseExp and EV yield values by Pokemon key for quick lookup
 	baseExpMap := make(map[string]int)
+	evYieldMap := make(map[string]pokemon.Stats)
+
+	// Rows that name a species differently from pokemondb are matched by
+	// any of its names, in any language we have. aliases maps the key the
+	// entry has to the key of the row.
+	species := speciesByName(pokemons)
+	keys := make(map[string]bool, len(pokemons))
+	for _, p := range pokemons {
+		keys[p.Key] = true
+	}
+	aliases := make(map[string]string)
 
 	// Find each Pokemon row in the table
 	doc.Find("table.sortable tbody tr").Each(func(i int, s *goquery.Selection) {
-		// Find Pokemon name
-		pokemonName := strings.TrimSpace(s.Find("td").Eq(2).Find("a").Text())
+		// Find Pokemon name and form
+		nameCell := s.Find("td").Eq(2)
+		pokemonName := strings.TrimSpace(nameCell.Find("a").First().Text())
+		pokemonForm := strings.TrimSpace(nameCell.Find("small").Text())
 
 		// Find and parse BaseExp
 		baseExpStr := strings.TrimSpace(s.Find("td").Eq(3).Text())
@@ -142,14 +205,38 @@ func fetchBaseExp(pokemons []Pokemon) error {
 			return
 		}
 
-		// Store BaseExp in the map
-		baseExpMap[pokemonName] = baseExp
+		// The EV yield columns follow: HP, Atk, Def, Sp.Atk, Sp.Def, Speed
+		cells := s.Find("td")
+		key := pokemon.Key(pokemonName, pokemonForm)
+		if name, ok := species[pokemon.Slugify(pokemonName)]; ok && !keys[key] {
+			if entry := pokemon.Key(name, pokemonForm); keys[entry] {
+				aliases[entry] = key
+			}
+		}
+		baseExpMap[key] = baseExp
+		evYieldMap[key] = pokemon.Stats{
+			HP:        parseIntOrDefault(cells.Eq(4).Text(), 0),
+			Attack:    parseIntOrDefault(cells.Eq(5).Text(), 0),
+			Defense:   parseIntOrDefault(cells.Eq(6).Text(), 0),
+			SpAttack:  parseIntOrDefault(cells.Eq(7).Text(), 0),
+			SpDefense: parseIntOrDefault(cells.Eq(8).Text(), 0),
+			Speed:     parseIntOrDefault(cells.Eq(9).Text(), 0),
+		}
 	})
 
-	// Assign BaseExp to corresponding Pokemon structs
+	// Assign BaseExp and EV yield to corresponding Pokemon structs. Forms
+	// Bulbapedia doesn't list separately share the base species' values.
 	for i := range pokemons {
-		if baseExp, ok := baseExpMap[pokemons[i].Name]; ok {
+		key := pokemons[i].Key
+		if _, ok := baseExpMap[key]; !ok {
+			key = pokemons[i].SpeciesKey()
+		}
+		if alias, ok := aliases[key]; ok {
+			key = alias
+		}
+		if baseExp, ok := baseExpMap[key]; ok {
 			pokemons[i].BaseExp = baseExp
+			pokemons[i].EVYield = evYieldMap[key]
 		}
 	}
 
@@ -157,8 +244,13 @@ func fetchBaseExp(pokemons []Pokemon) error {
 }
 
 // Save pokedex to a JSON file
-func savePokedex(pokedex Pokedex) error {
-	f, err := os.Create(pokedexFile)
+func savePokedex(pokedex pokemon.Pokedex) error {
+	return saveJSON(pokedexFile, pokedex.Pokemons)
+}
+
+// Save any value as an indented JSON file
+func saveJSON(name string, v interface{}) error {
+	f, err := os.Create(name)
 	if err != nil {
 		return fmt.Errorf("creating file: %w", err)
 	}
@@ -168,41 +260,17 @@ func savePokedex(pokedex Pokedex) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 
-	if err := enc.Encode(pokedex.Pokemons); err != nil {
-		return fmt.Errorf("encoding pokedex: %w", err)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encoding %s: %w", name, err)
 	}
 
 	return w.Flush()
 }
 
 // Load pokedex from a JSON file
-func loadPokedex() (Pokedex, error) {
-	data, err := ioutil.ReadFile(pokedexFile)
-	if err != nil {
-		return Pokedex{}, err
-	}
-	var pokedex Pokedex
-	err = json.Unmarshal(data, &pokedex.Pokemons)
-	return pokedex, err
-}
-
-func levelUp(pokemon *Pokemon) {
-	// Calculate required experience points for the next level
-	requiredExp := pokemon.BaseExp * (1 << (pokemon.Level - 1))
-
-	// Check if the Pokemon has enough experience points to level up
-	if pokemon.Experience >= requiredExp {
-		pokemon.Level++
-		pokemon.Experience -= requiredExp // Deduct required experience points
-		evMultiplier := 1.0 + pokemon.EV  // EV multiplier
-
-		// Increase attributes based on the EV multiplier
-		pokemon.Attributes.HP = int(float64(pokemon.Attributes.HP) * evMultiplier)
-		pokemon.Attributes.Attack = int(float64(pokemon.Attributes.Attack) * evMultiplier)
-		pokemon.Attributes.Defense = int(float64(pokemon.Attributes.Defense) * evMultiplier)
-		pokemon.Attributes.SpAttack = int(float64(pokemon.Attributes.SpAttack) * evMultiplier)
-		pokemon.Attributes.SpDefense = int(float64(pokemon.Attributes.SpDefense) * evMultiplier)
-	}
+func loadPokedex() (pokemon.Pokedex, error) {
+	pokemons, err := pokemon.Load(pokedexFile)
+	return pokemon.Pokedex{Pokemons: pokemons}, err
 }
 
 // Add error handling helper
@@ -210,18 +278,27 @@ func parseAttribute(s string) (int, error) {
 	return strconv.Atoi(strings.TrimSpace(s))
 }
 
-// Optimize attribute parsing
-func (p *Pokemon) parseAttributes(s *goquery.Selection) {
+// Optimize attribute parsing. The numeric cells of a row are, in order:
+// #, Total, HP, Attack, Defense, Sp. Atk, Sp. Def, Speed.
+func parseAttributes(p *pokemon.Pokemon, s *goquery.Selection) {
 	attrs := s.Find("td.cell-num")
-	if attrs.Length() >= 8 {
-		p.Attributes = Attributes{
-			HP:        parseIntOrDefault(attrs.Eq(2).Text(), 0),
-			Attack:    parseIntOrDefault(attrs.Eq(3).Text(), 0),
-			Defense:   parseIntOrDefault(attrs.Eq(4).Text(), 0),
-			SpAttack: parseIntOrDefault(attrs.Eq(5).Text(), 0),
-			SpDefense: parseIntOrDefault(attrs.Eq(6).Text(), 0),
-			Speed:     parseIntOrDefault(attrs.Eq(7).Text(), 0),
-		}
+	if attrs.Length() < 8 {
+		return
+	}
+
+	number, ok := attrs.Eq(0).Attr("data-sort-value")
+	if !ok {
+		number = attrs.Eq(0).Text()
+	}
+	p.Number = parseIntOrDefault(number, 0)
+	p.Total = parseIntOrDefault(attrs.Eq(1).Text(), 0)
+	p.Attributes = pokemon.Attributes{
+		HP:        parseIntOrDefault(attrs.Eq(2).Text(), 0),
+		Attack:    parseIntOrDefault(attrs.Eq(3).Text(), 0),
+		Defense:   parseIntOrDefault(attrs.Eq(4).Text(), 0),
+		SpAttack:  parseIntOrDefault(attrs.Eq(5).Text(), 0),
+		SpDefense: parseIntOrDefault(attrs.Eq(6).Text(), 0),
+		Speed:     parseIntOrDefault(attrs.Eq(7).Text(), 0),
 	}
 }
 
@@ -234,41 +311,172 @@ func parseIntOrDefault(s string, defaultVal int) int {
 }
 
 func main() {
+	ran, err := runCommand(os.Args[1:])
+	if !ran {
+		err = runScrape()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// runScrape is what the Pokedex command does without a command: scrape the
+// pokedex and write the outputs its flags ask for.
+func runScrape() error {
+	fixtures := flag.String("fixtures", os.Getenv("POKEDEX_FIXTURES"), "read source pages from this directory instead of the network")
+	pokemondb := flag.String("pokemondb-url", pokemondbURL, "base URL of pokemondb.net")
+	bulbapedia := flag.String("bulbapedia-url", bulbapediaURL, "base URL of bulbapedia.bulbagarden.net")
+	update := flag.Bool("update", false, "merge into the existing "+pokedexFile+", keeping exp/level/ivs/evs/nature/ability, and print what changed")
+	dryRun := flag.Bool("dry-run", false, "with -update, print the changes without writing any file")
+	prune := flag.Bool("prune", false, "with -update, drop saved entries the scrape no longer finds")
+	details := flag.Bool("details", false, "also crawl every species' detail page for growth rates, abilities and names, and write "+movesFile+", "+learnsetsFile+" and "+evolutionsFile)
+	types := flag.Bool("types", false, "also scrape the type chart into "+typesFile)
+	concurrency := flag.Int("concurrency", crawlConcurrency, "detail pages to fetch at once")
+	delay := flag.Duration("delay", crawlDelay, "minimum time between detail page requests")
+	timeout := flag.Duration("timeout", fetchTimeout, "timeout for each HTTP request")
+	retries := flag.Int("retries", fetchRetries, "retries for 429 and 5xx responses, with exponential backoff")
+	cacheDir := flag.String("cache", fetchCacheDir, "directory for cached pages, revalidated on each run (empty to disable)")
+	threshold := flag.Int("max-anomalies", maxAnomalies, "fail without saving if validation finds more anomalies than this")
+	reportFile := flag.String("report", validationFile, "where to write the validation report")
+	dbFile := flag.String("db", "", "also import the saved entries into this store database, e.g. pokedex.db")
+	importOnly := flag.Bool("import", false, "with -db, import the existing "+pokedexFile+" without scraping")
+	artworkDir := flag.String("assets", "", "also download every entry's artwork from its detail page into this directory, e.g. "+assetsDir+", with a "+assets.ManifestFile+" for the world servers")
+	flag.Usage = commandUsage
+	flag.Parse()
+
+	if *importOnly {
+		if *dbFile == "" {
+			return errors.New("-import needs -db")
+		}
+		pokedex, err := loadPokedex()
+		if err != nil {
+			return fmt.Errorf("loading pokedex: %w", err)
+		}
+		if err := importPokedex(*dbFile, pokedex.Pokemons); err != nil {
+			return fmt.Errorf("importing pokedex: %w", err)
+		}
+		fmt.Printf("Imported %d entries into %s\n", len(pokedex.Pokemons), *dbFile)
+		return nil
+	}
+
 	rand.Seed(time.Now().UnixNano())
 
+	s := newScraper()
+	if *fixtures != "" {
+		s = newFixtureScraper(*fixtures)
+	} else {
+		s.pokemondbURL = *pokemondb
+		s.bulbapediaURL = *bulbapedia
+		s.delay = *delay
+		s.fetch.client.Timeout = *timeout
+		s.fetch.cacheDir = *cacheDir
+	}
+	s.fetch.retries = *retries
+	s.concurrency = *concurrency
+
 	// Fetch and save pokedex
-	pokemons, err := fetchPokemonData(context.Background())
+	ctx := context.Background()
+	pokemons, sources, err := s.fetchPokemonData(ctx)
 	if err != nil {
-		fmt.Println("Error fetching pokemon data:", err)
-		return
+		return fmt.Errorf("fetching pokemon data: %w", err)
 	}
 
-	err = fetchBaseExp(pokemons)
-	if err != nil {
-		fmt.Println("Error fetching base exp data:", err)
-		return
+	// Detail pages fill in fields of the entries, names included, so
+	// crawl them before matching Bulbapedia's rows, validating and saving
+	var pages map[string]detailPage
+	if *details || *artworkDir != "" {
+		pages, err = s.fetchDetails(ctx, pokemons, sources)
+		if err != nil {
+			return fmt.Errorf("fetching details: %w", err)
+		}
+		applyDetails(pokemons, pages)
+	} else if saved, err := loadPokedex(); err == nil {
+		restoreDetails(pokemons, saved.Pokemons)
 	}
 
-	pokedex := Pokedex{Pokemons: pokemons}
-	err = savePokedex(pokedex)
+	err = s.fetchBaseExp(ctx, pokemons)
 	if err != nil {
-		fmt.Println("Error saving pokedex:", err)
-		return
+		return fmt.Errorf("fetching base exp data: %w", err)
 	}
 
-	// Load pokedex
-	pokedex, err = loadPokedex()
-	if err != nil {
-		fmt.Println("Error loading pokedex:", err)
-		return
+	// A dry run writes no file at all, the report included
+	dry := *update && *dryRun
+
+	report := validatePokedex(pokemons, sources, *threshold)
+	if !dry {
+		if err := saveJSON(*reportFile, report); err != nil {
+			return fmt.Errorf("saving validation report: %w", err)
+		}
+	}
+	if len(report.Anomalies) > 0 {
+		fmt.Printf("Validation found %d anomalies in %d entries", len(report.Anomalies), report.Checked)
+		if !dry {
+			fmt.Printf(", see %s", *reportFile)
+		}
+		fmt.Println()
 	}
+	if !report.Passed {
+		return fmt.Errorf("too many anomalies (more than %d), not saving", report.Threshold)
+	}
+	pokemons = uniquePokedex(pokemons)
+
+	// An update with nothing new leaves pokedex.json alone but still
+	// writes the other outputs asked for
+	changed := true
+	if *update {
+		// Load pokedex and merge the fresh data into it
+		pokedex, err := loadPokedex()
+		if err != nil {
+			return fmt.Errorf("loading pokedex: %w", err)
+		}
 
-	// Save updated pokedex
-	err = savePokedex(pokedex)
-	if err != nil {
-		fmt.Println("Error saving updated pokedex:", err)
-		return
+		merged, diff := mergePokedex(pokedex.Pokemons, pokemons, *prune)
+		printDiff(os.Stdout, diff, merged)
+		if *dryRun {
+			return nil
+		}
+		pokemons = merged
+		changed = !diff.empty()
+	}
+
+	if changed {
+		err = savePokedex(pokemon.Pokedex{Pokemons: pokemons})
+		if err != nil {
+			return fmt.Errorf("saving pokedex: %w", err)
+		}
+	}
+
+	if *dbFile != "" {
+		if err := importPokedex(*dbFile, pokemons); err != nil {
+			return fmt.Errorf("importing pokedex: %w", err)
+		}
+	}
+
+	if *types {
+		chart, err := s.fetchTypeChart(ctx)
+		if err != nil {
+			return fmt.Errorf("fetching type chart: %w", err)
+		}
+		if err := saveJSON(typesFile, chart); err != nil {
+			return fmt.Errorf("saving type chart: %w", err)
+		}
+	}
+
+	if *artworkDir != "" {
+		downloaded, err := s.downloadArtwork(ctx, *artworkDir, pokemons, pages)
+		if err != nil {
+			return fmt.Errorf("downloading artwork: %w", err)
+		}
+		fmt.Printf("Downloaded %d images into %s\n", downloaded, *artworkDir)
+	}
+
+	if *details {
+		if err := saveDetails(ctx, s, pokemons, pages); err != nil {
+			return fmt.Errorf("saving details: %w", err)
+		}
 	}
 
 	fmt.Println("Pokedex saved successfully!")
+	return nil
 }
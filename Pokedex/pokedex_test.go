@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// fixtureEntries are some of the entries the pages in testdata give.
+var fixtureEntries = map[string]pokemon.Pokemon{
+	"bulbasaur": {
+		Key: "bulbasaur", Number: 1, Name: "Bulbasaur", Type: []string{"Grass", "Poison"}, Total: 318, BaseExp: 64, Level: 1,
+		EVYield:    pokemon.Stats{SpAttack: 1},
+		Attributes: pokemon.Attributes{HP: 45, Attack: 49, Defense: 49, SpAttack: 65, SpDefense: 65, Speed: 45},
+	},
+	"charizard-mega-x": {
+		Key: "charizard-mega-x", Number: 6, Name: "Charizard", Form: "Mega Charizard X", Type: []string{"Fire", "Dragon"}, Total: 634, BaseExp: 285, Level: 1,
+		EVYield:    pokemon.Stats{Attack: 3},
+		Attributes: pokemon.Attributes{HP: 78, Attack: 130, Defense: 111, SpAttack: 130, SpDefense: 85, Speed: 100},
+	},
+	"meowth-galarian": {
+		Key: "meowth-galarian", Number: 52, Name: "Meowth", Form: "Galarian Meowth", Type: []string{"Steel"}, Total: 290, BaseExp: 58, Level: 1,
+		EVYield:    pokemon.Stats{Attack: 1},
+		Attributes: pokemon.Attributes{HP: 50, Attack: 65, Defense: 55, SpAttack: 40, SpDefense: 40, Speed: 40},
+	},
+	"raichu-alolan": {
+		Key: "raichu-alolan", Number: 26, Name: "Raichu", Form: "Alolan Raichu", Type: []string{"Electric", "Psychic"}, Total: 485, BaseExp: 243, Level: 1,
+		EVYield:    pokemon.Stats{Speed: 3},
+		Attributes: pokemon.Attributes{HP: 60, Attack: 85, Defense: 50, SpAttack: 95, SpDefense: 85, Speed: 110},
+	},
+}
+
+// fixtureKeys is every key the pages in testdata give, in pokedex order.
+var fixtureKeys = []string{
+	"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon",
+	"charizard", "charizard-mega-x", "charizard-mega-y", "pikachu",
+	"raichu", "raichu-alolan", "meowth", "meowth-alolan", "meowth-galarian",
+	"persian",
+}
+
+// scrape runs the table and base experience steps of the pipeline, the
+// ones every run of the scraper does.
+func scrape(t *testing.T, s *scraper) []pokemon.Pokemon {
+	t.Helper()
+	ctx := context.Background()
+	pokemons, sources, err := s.fetchPokemonData(ctx)
+	if err != nil {
+		t.Fatalf("fetchPokemonData: %v", err)
+	}
+	for i, p := range pokemons {
+		if sources[i].detailPath == "" {
+			t.Errorf("%s has no detail page", p.Key)
+		}
+	}
+	if err := s.fetchBaseExp(ctx, pokemons); err != nil {
+		t.Fatalf("fetchBaseExp: %v", err)
+	}
+	return uniquePokedex(pokemons)
+}
+
+func checkEntries(t *testing.T, pokemons []pokemon.Pokemon) {
+	t.Helper()
+	keys := make([]string, len(pokemons))
+	for i, p := range pokemons {
+		keys[i] = p.Key
+		if want, ok := fixtureEntries[p.Key]; ok && !reflect.DeepEqual(p, want) {
+			t.Errorf("%s:\n got %+v\nwant %+v", p.Key, p, want)
+		}
+	}
+	if !reflect.DeepEqual(keys, fixtureKeys) {
+		t.Errorf("keys = %v, want %v", keys, fixtureKeys)
+	}
+}
+
+func TestFixtureScraper(t *testing.T) {
+	checkEntries(t, scrape(t, newFixtureScraper("testdata")))
+}
+
+func TestScraperHTTP(t *testing.T) {
+	pokemondb := httptest.NewServer(http.FileServer(http.Dir("testdata/pokemondb.net")))
+	defer pokemondb.Close()
+	bulbapedia := httptest.NewServer(http.FileServer(http.Dir("testdata/bulbapedia.bulbagarden.net")))
+	defer bulbapedia.Close()
+
+	s := newScraper()
+	s.pokemondbURL = pokemondb.URL
+	s.bulbapediaURL = bulbapedia.URL
+	checkEntries(t, scrape(t, s))
+}
+
+// row wraps the cells of a row of the pokemondb "all" table.
+func row(t *testing.T, cells string) *goquery.Selection {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<table class="data-table"><tbody><tr>` + cells + `</tr></tbody></table>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc.Find("tr")
+}
+
+func TestParsePokemonRow(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells string
+		want  pokemon.Pokemon
+		path  string
+	}{
+		{
+			name: "base form",
+			cells: `<td class="cell-num cell-fixed" data-sort-value="1"><span class="infocard-cell-data">0001</span></td>
+<td class="cell-name"><a class="ent-name" href="/pokedex/bulbasaur">Bulbasaur</a></td>
+<td class="cell-icon"><a class="type-icon type-grass" href="/type/grass">Grass</a><br> <a class="type-icon type-poison" href="/type/poison">Poison</a></td>
+<td class="cell-num cell-total">318</td>
+<td class="cell-num">45</td><td class="cell-num">49</td><td class="cell-num">49</td>
+<td class="cell-num">65</td><td class="cell-num">65</td><td class="cell-num">45</td>`,
+			want: pokemon.Pokemon{
+				Key: "bulbasaur", Number: 1, Name: "Bulbasaur", Type: []string{"Grass", "Poison"}, Total: 318, Level: 1,
+				Attributes: pokemon.Attributes{HP: 45, Attack: 49, Defense: 49, SpAttack: 65, SpDefense: 65, Speed: 45},
+			},
+			path: "/pokedex/bulbasaur",
+		},
+		{
+			name: "form",
+			cells: `<td class="cell-num cell-fixed" data-sort-value="6"><span class="infocard-cell-data">0006</span></td>
+<td class="cell-name"><a class="ent-name" href="/pokedex/charizard">Charizard</a><br><small class="text-muted">Mega Charizard X</small></td>
+<td class="cell-icon"><a class="type-icon type-fire" href="/type/fire">Fire</a><br> <a class="type-icon type-dragon" href="/type/dragon">Dragon</a></td>
+<td class="cell-num cell-total">634</td>
+<td class="cell-num">78</td><td class="cell-num">130</td><td class="cell-num">111</td>
+<td class="cell-num">130</td><td class="cell-num">85</td><td class="cell-num">100</td>`,
+			want: pokemon.Pokemon{
+				Key: "charizard-mega-x", Number: 6, Name: "Charizard", Form: "Mega Charizard X", Type: []string{"Fire", "Dragon"}, Total: 634, Level: 1,
+				Attributes: pokemon.Attributes{HP: 78, Attack: 130, Defense: 111, SpAttack: 130, SpDefense: 85, Speed: 100},
+			},
+			path: "/pokedex/charizard",
+		},
+		{
+			name: "number without sort value",
+			cells: `<td class="cell-num cell-fixed">0025</td>
+<td class="cell-name"><a class="ent-name" href="/pokedex/pikachu">Pikachu</a></td>
+<td class="cell-icon"><a class="type-icon type-electric" href="/type/electric">Electric</a></td>
+<td class="cell-num cell-total">320</td>
+<td class="cell-num">35</td><td class="cell-num">55</td><td class="cell-num">40</td>
+<td class="cell-num">50</td><td class="cell-num">50</td><td class="cell-num">90</td>`,
+			want: pokemon.Pokemon{
+				Key: "pikachu", Number: 25, Name: "Pikachu", Type: []string{"Electric"}, Total: 320, Level: 1,
+				Attributes: pokemon.Attributes{HP: 35, Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50, Speed: 90},
+			},
+			path: "/pokedex/pikachu",
+		},
+		{
+			name: "unreadable stat",
+			cells: `<td class="cell-num cell-fixed" data-sort-value="52">0052</td>
+<td class="cell-name"><a class="ent-name" href="/pokedex/meowth">Meowth</a></td>
+<td class="cell-icon"><a class="type-icon type-normal" href="/type/normal">Normal</a></td>
+<td class="cell-num cell-total">290</td>
+<td class="cell-num">40</td><td class="cell-num">—</td><td class="cell-num">35</td>
+<td class="cell-num">40</td><td class="cell-num">40</td><td class="cell-num">90</td>`,
+			want: pokemon.Pokemon{
+				Key: "meowth", Number: 52, Name: "Meowth", Type: []string{"Normal"}, Total: 290, Level: 1,
+				Attributes: pokemon.Attributes{HP: 40, Defense: 35, SpAttack: 40, SpDefense: 40, Speed: 90},
+			},
+			path: "/pokedex/meowth",
+		},
+		{
+			name: "fewer than 8 cells",
+			cells: `<td class="cell-num cell-fixed" data-sort-value="26">0026</td>
+<td class="cell-name"><a class="ent-name" href="/pokedex/raichu">Raichu</a><br><small class="text-muted">Alolan Raichu</small></td>
+<td class="cell-icon"><a class="type-icon type-electric" href="/type/electric">Electric</a></td>
+<td class="cell-num cell-total">485</td>
+<td class="cell-num">60</td><td class="cell-num">85</td>`,
+			want: pokemon.Pokemon{Key: "raichu-alolan", Name: "Raichu", Form: "Alolan Raichu", Type: []string{"Electric"}, Level: 1},
+			path: "/pokedex/raichu",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, src := parsePokemonRow(row(t, tt.cells))
+			if !reflect.DeepEqual(p, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", p, tt.want)
+			}
+			if src.detailPath != tt.path {
+				t.Errorf("detail path = %q, want %q", src.detailPath, tt.path)
+			}
+			if !strings.Contains(src.row, "cell-name") {
+				t.Errorf("source row %q is not the row's HTML", src.row)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/thanhduy1706/PokeDBC/typechart"
+)
+
+const (
+	typeChartPath = "/type"
+	typesFile     = "types.json"
+)
+
+// fetchTypeChart reads the attack/defense grid on pokemondb's type page.
+// Each cell carries its multiplier as a class: type-fx-0, -50, -100, -200.
+func (s *scraper) fetchTypeChart(ctx context.Context) (*typechart.Chart, error) {
+	doc, err := s.fetchDocument(ctx, s.pokemondbURL+typeChartPath)
+	if err != nil {
+		return nil, err
+	}
+
+	table := doc.Find("table.type-table").First()
+
+	// The header row lists the defending types, abbreviated, with the full
+	// name in the title attribute
+	var types []string
+	table.Find("thead th a").Each(func(i int, a *goquery.Selection) {
+		types = append(types, strings.TrimSpace(a.AttrOr("title", a.Text())))
+	})
+
+	rows := make(map[string]map[string]float64)
+	var parseErr error
+	table.Find("tbody tr").Each(func(i int, tr *goquery.Selection) {
+		attack := strings.TrimSpace(tr.Find("th a").Text())
+		row := make(map[string]float64)
+		tr.Find("td").Each(func(j int, td *goquery.Selection) {
+			if j >= len(types) {
+				return
+			}
+			m, err := typeFx(td)
+			if err != nil {
+				parseErr = fmt.Errorf("%s -> %s: %w", attack, types[j], err)
+				return
+			}
+			row[types[j]] = m
+		})
+		rows[attack] = row
+	})
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	return typechart.Build(types, rows)
+}
+
+func typeFx(td *goquery.Selection) (float64, error) {
+	for _, class := range strings.Fields(td.AttrOr("class", "")) {
+		if pct, ok := strings.CutPrefix(class, "type-fx-"); ok {
+			if n, err := strconv.Atoi(pct); err == nil {
+				return float64(n) / 100, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no type-fx class in %q", td.AttrOr("class", ""))
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// typeSummary is how many entries have a type and their average base
+// stats. Dual-type entries count towards both types.
+type typeSummary struct {
+	Type    string             `json:"type"`
+	Count   int                `json:"count"`
+	Average map[string]float64 `json:"average"` // by pokemon.StatNames
+}
+
+// typeBreakdown summarizes pokemons per type, most common type first.
+func typeBreakdown(pokemons []pokemon.Pokemon) []typeSummary {
+	sums := make(map[string]map[string]int)
+	counts := make(map[string]int)
+	for _, p := range pokemons {
+		for _, t := range p.Type {
+			if sums[t] == nil {
+				sums[t] = make(map[string]int)
+			}
+			counts[t]++
+			for _, stat := range pokemon.StatNames {
+				v, _ := p.Stat(stat)
+				sums[t][stat] += v
+			}
+		}
+	}
+
+	summaries := make([]typeSummary, 0, len(counts))
+	for t, count := range counts {
+		s := typeSummary{Type: t, Count: count, Average: make(map[string]float64)}
+		for stat, sum := range sums[t] {
+			// One decimal is plenty for comparing types
+			s.Average[stat] = math.Round(float64(sum)/float64(count)*10) / 10
+		}
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].Type < summaries[j].Type
+	})
+	return summaries
+}
+
+func writeTypeSummaries(w io.Writer, format string, summaries []typeSummary) error {
+	header := []string{"Type", "Count"}
+	for _, stat := range pokemon.StatNames {
+		header = append(header, "Avg "+statLabels[stat])
+	}
+	rows := make([][]string, len(summaries))
+	for i, s := range summaries {
+		row := []string{s.Type, strconv.Itoa(s.Count)}
+		for _, stat := range pokemon.StatNames {
+			row = append(row, fmt.Sprintf("%.1f", s.Average[stat]))
+		}
+		rows[i] = row
+	}
+	return writeRows(w, format, summaries, header, rows)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// pokedexDiff lists how a fresh scrape differs from the saved pokedex.
+// Entries the scrape no longer finds are Removed when pruning and Kept
+// otherwise.
+type pokedexDiff struct {
+	Added   []pokemon.Pokemon
+	Removed []pokemon.Pokemon
+	Kept    []pokemon.Pokemon
+	Changed map[string][]string // key -> human readable field changes
+}
+
+func (d pokedexDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// mergePokedex takes the species data from fresh and keeps the fields we
+// edit by hand (exp, level, ivs, evs, nature, ability) from the matching
+// entries of old. Entries of old that fresh lacks stay as they are, in
+// dex order, unless prune is set.
+func mergePokedex(old, fresh []pokemon.Pokemon, prune bool) ([]pokemon.Pokemon, pokedexDiff) {
+	diff := pokedexDiff{Changed: make(map[string][]string)}
+
+	oldByKey := make(map[string]pokemon.Pokemon, len(old))
+	for _, p := range old {
+		if _, ok := oldByKey[p.Key]; !ok {
+			oldByKey[p.Key] = p
+		}
+	}
+
+	merged := make([]pokemon.Pokemon, 0, len(fresh))
+	seen := make(map[string]bool, len(fresh))
+	for _, p := range fresh {
+		seen[p.Key] = true
+		prev, ok := oldByKey[p.Key]
+		if !ok {
+			diff.Added = append(diff.Added, p)
+			merged = append(merged, p)
+			continue
+		}
+
+		// Runs without -details keep what the detail pages gave last time
+		restoreDetail(&p, prev)
+		if changes := changedFields(prev, p); len(changes) > 0 {
+			diff.Changed[p.Key] = changes
+		}
+		p.Experience = prev.Experience
+		p.Level = prev.Level
+		p.IVs = prev.IVs
+		p.EVs = prev.EVs
+		p.Nature = prev.Nature
+		p.Ability = prev.Ability
+		p.Moves = prev.Moves
+		merged = append(merged, p)
+	}
+
+	kept := false
+	for _, p := range old {
+		if seen[p.Key] {
+			continue
+		}
+		seen[p.Key] = true
+		if prune {
+			diff.Removed = append(diff.Removed, p)
+			continue
+		}
+		diff.Kept = append(diff.Kept, p)
+		merged = append(merged, p)
+		kept = true
+	}
+	if kept {
+		sort.SliceStable(merged, func(i, j int) bool { return merged[i].Number < merged[j].Number })
+	}
+
+	return merged, diff
+}
+
+// mergeNames adds the freshly scraped names to the saved ones, keeping
+// the locales pokemondb doesn't list.
+func mergeNames(old, fresh pokemon.Names) pokemon.Names {
+	if len(old) == 0 {
+		return fresh
+	}
+	merged := make(pokemon.Names, len(old)+len(fresh))
+	for locale, name := range old {
+		merged[locale] = name
+	}
+	for locale, name := range fresh {
+		merged[locale] = name
+	}
+	return merged
+}
+
+// changedFields compares the scraped species data of two entries.
+func changedFields(old, fresh pokemon.Pokemon) []string {
+	var changes []string
+	field := func(name string, a, b interface{}) {
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, fmt.Sprintf("%s: %v -> %v", name, a, b))
+		}
+	}
+
+	field("number", old.Number, fresh.Number)
+	field("name", old.Name, fresh.Name)
+	field("form", old.Form, fresh.Form)
+	field("type", old.Type, fresh.Type)
+	field("total", old.Total, fresh.Total)
+	field("base_exp", old.BaseExp, fresh.BaseExp)
+	field("growth_rate", old.GrowthRate, fresh.GrowthRate)
+	field("names", old.Names, fresh.Names)
+	field("abilities", old.Abilities, fresh.Abilities)
+	field("ev_yield", old.EVYield, fresh.EVYield)
+	field("attributes", old.Attributes, fresh.Attributes)
+	return changes
+}
+
+// printDiff writes the diff in a form that is easy to review before
+// committing the new pokedex.json.
+func printDiff(w io.Writer, d pokedexDiff, merged []pokemon.Pokemon) {
+	if d.empty() && len(d.Kept) == 0 {
+		fmt.Fprintln(w, "No changes.")
+		return
+	}
+
+	fmt.Fprintf(w, "Added (%d):\n", len(d.Added))
+	for _, p := range d.Added {
+		fmt.Fprintf(w, "  + %s\n", p.Key)
+	}
+
+	fmt.Fprintf(w, "Removed (%d):\n", len(d.Removed))
+	for _, p := range d.Removed {
+		fmt.Fprintf(w, "  - %s\n", p.Key)
+	}
+	if len(d.Kept) > 0 {
+		fmt.Fprintf(w, "Not scraped, kept (%d, -prune removes them):\n", len(d.Kept))
+		for _, p := range d.Kept {
+			fmt.Fprintf(w, "  = %s\n", p.Key)
+		}
+	}
+
+	// Walk the merged list so changes come out in dex order
+	fmt.Fprintf(w, "Changed (%d):\n", len(d.Changed))
+	for _, p := range merged {
+		for _, change := range d.Changed[p.Key] {
+			fmt.Fprintf(w, "  ~ %s %s\n", p.Key, change)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// savedPokedex is a pokedex.json with hand-edited entries, against which
+// updatePokedex is a fresh scrape without detail pages: pikachu's total
+// changed, raichu is new and meowth is gone.
+var (
+	savedPokedex = []pokemon.Pokemon{
+		{
+			Key: "bulbasaur", Number: 1, Name: "Bulbasaur", Total: 318, GrowthRate: pokemon.MediumSlow,
+			Abilities:  []pokemon.Ability{{Name: "Overgrow"}},
+			Names:      pokemon.Names{"ja": "フシギダネ"},
+			Experience: 560, Level: 8, IVs: pokemon.Stats{HP: 31}, EVs: pokemon.Stats{SpAttack: 4}, Nature: "modest", Ability: "Overgrow",
+		},
+		{Key: "pikachu", Number: 25, Name: "Pikachu", Total: 300, GrowthRate: pokemon.MediumFast, Level: 1},
+		{Key: "meowth", Number: 52, Name: "Meowth", Total: 290, Experience: 1000, Level: 10, Nature: "jolly"},
+	}
+	updatePokedex = []pokemon.Pokemon{
+		{Key: "bulbasaur", Number: 1, Name: "Bulbasaur", Total: 318, Level: 1},
+		{Key: "pikachu", Number: 25, Name: "Pikachu", Total: 320, Level: 1},
+		{Key: "raichu", Number: 26, Name: "Raichu", Total: 485, Level: 1},
+	}
+)
+
+func TestMergePokedex(t *testing.T) {
+	bulbasaur, pikachu, meowth := savedPokedex[0], savedPokedex[1], savedPokedex[2]
+	pikachu.Total = 320
+	raichu := updatePokedex[2]
+
+	tests := []struct {
+		name   string
+		prune  bool
+		want   []pokemon.Pokemon
+		remove []pokemon.Pokemon
+		kept   []pokemon.Pokemon
+	}{
+		{"keep", false, []pokemon.Pokemon{bulbasaur, pikachu, raichu, meowth}, nil, []pokemon.Pokemon{meowth}},
+		{"prune", true, []pokemon.Pokemon{bulbasaur, pikachu, raichu}, []pokemon.Pokemon{meowth}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, diff := mergePokedex(savedPokedex, updatePokedex, tt.prune)
+			if !reflect.DeepEqual(merged, tt.want) {
+				t.Errorf("merged:\n got %+v\nwant %+v", merged, tt.want)
+			}
+			if !reflect.DeepEqual(diff.Added, []pokemon.Pokemon{raichu}) {
+				t.Errorf("added %+v, want raichu", diff.Added)
+			}
+			if !reflect.DeepEqual(diff.Removed, tt.remove) || !reflect.DeepEqual(diff.Kept, tt.kept) {
+				t.Errorf("removed %+v, kept %+v; want %+v, %+v", diff.Removed, diff.Kept, tt.remove, tt.kept)
+			}
+			want := map[string][]string{"pikachu": {"total: 300 -> 320"}}
+			if !reflect.DeepEqual(diff.Changed, want) {
+				t.Errorf("changed %v, want %v", diff.Changed, want)
+			}
+		})
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	tests := []struct {
+		name  string
+		prune bool
+		want  string
+	}{
+		{"keep", false, `Added (1):
+  + raichu
+Removed (0):
+Not scraped, kept (1, -prune removes them):
+  = meowth
+Changed (1):
+  ~ pikachu total: 300 -> 320
+`},
+		{"prune", true, `Added (1):
+  + raichu
+Removed (1):
+  - meowth
+Changed (1):
+  ~ pikachu total: 300 -> 320
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, diff := mergePokedex(savedPokedex, updatePokedex, tt.prune)
+			var buf bytes.Buffer
+			printDiff(&buf, diff, merged)
+			if buf.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	merged, diff := mergePokedex(savedPokedex[:2], savedPokedex[:2], false)
+	printDiff(&buf, diff, merged)
+	if buf.String() != "No changes.\n" {
+		t.Errorf("same pokedex: got %q", buf.String())
+	}
+
+	buf.Reset()
+	merged, diff = mergePokedex(savedPokedex[:2], savedPokedex[:1], false)
+	printDiff(&buf, diff, merged)
+	if want := "Added (0):\nRemoved (0):\nNot scraped, kept (1, -prune removes them):\n  = pikachu\nChanged (0):\n"; buf.String() != want {
+		t.Errorf("kept only: got %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+const (
+	validationFile = "validation.json"
+	maxAnomalies   = 25
+)
+
+// Anomaly is one suspicious value in the scraped data. Row is the HTML of
+// the pokemondb table row the entry was parsed from.
+type Anomaly struct {
+	Key     string `json:"key"`
+	Check   string `json:"check"`
+	Message string `json:"message"`
+	Row     string `json:"row,omitempty"`
+}
+
+// ValidationReport is written as JSON after every scrape so CI can pick
+// up the anomalies without parsing log output.
+type ValidationReport struct {
+	Checked   int       `json:"checked"`
+	Threshold int       `json:"threshold"`
+	Passed    bool      `json:"passed"`
+	Anomalies []Anomaly `json:"anomalies"`
+}
+
+// validatePokedex flags entries that parsed into empty or default values:
+// missing names or types, stats left at 0 by parseIntOrDefault, totals
+// that don't add up, and BaseExp left at 0 because fetchBaseExp found no
+// matching Bulbapedia row, as well as entries whose key an earlier one
+// already has. It checks every scraped row, before uniquePokedex. The
+// report passes while there are at most threshold anomalies; sources
+// supplies the rows they were parsed from, by index.
+func validatePokedex(pokemons []pokemon.Pokemon, sources []source, threshold int) ValidationReport {
+	report := ValidationReport{
+		Checked:   len(pokemons),
+		Threshold: threshold,
+		Anomalies: []Anomaly{},
+	}
+	flag := func(i int, check, format string, args ...interface{}) {
+		report.Anomalies = append(report.Anomalies, Anomaly{
+			Key:     pokemons[i].Key,
+			Check:   check,
+			Message: fmt.Sprintf(format, args...),
+			Row:     sources[i].row,
+		})
+	}
+
+	seen := make(map[string]int) // key -> index of the first entry with it
+	for i, p := range pokemons {
+		if first, ok := seen[p.Key]; ok {
+			flag(i, "key", "same key as entry %d", first+1)
+		} else {
+			seen[p.Key] = i
+		}
+		if p.Name == "" {
+			flag(i, "name", "missing name")
+		}
+		if p.Number <= 0 {
+			flag(i, "number", "missing national dex number")
+		}
+		if len(p.Type) == 0 {
+			flag(i, "type", "no types")
+		}
+
+		stats := []struct {
+			name  string
+			value int
+		}{
+			{"hp", p.Attributes.HP},
+			{"attack", p.Attributes.Attack},
+			{"defense", p.Attributes.Defense},
+			{"sp_attack", p.Attributes.SpAttack},
+			{"sp_defense", p.Attributes.SpDefense},
+			{"speed", p.Attributes.Speed},
+		}
+		sum := 0
+		for _, stat := range stats {
+			if stat.value <= 0 {
+				flag(i, "stats", "%s is %d", stat.name, stat.value)
+			}
+			sum += stat.value
+		}
+		if sum != p.Total {
+			flag(i, "total", "stats add up to %d but total is %d", sum, p.Total)
+		}
+
+		if p.BaseExp <= 0 {
+			flag(i, "base_exp", "no base exp, %s has no matching Bulbapedia row", p.Name)
+		}
+	}
+
+	report.Passed = len(report.Anomalies) <= threshold
+	return report
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+func TestValidatePokedexChecksEveryRow(t *testing.T) {
+	good := pokemon.Pokemon{
+		Key: "pikachu", Number: 25, Name: "Pikachu", Type: []string{"Electric"}, Total: 320, BaseExp: 112,
+		Attributes: pokemon.Attributes{HP: 35, Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50, Speed: 90},
+	}
+	nameless := good
+	nameless.Key, nameless.Name = "", ""
+
+	pokemons := []pokemon.Pokemon{good, nameless, nameless, good}
+	sources := []source{{row: "<tr>pikachu</tr>"}, {row: "<tr>first</tr>"}, {row: "<tr>second</tr>"}, {row: "<tr>again</tr>"}}
+	report := validatePokedex(pokemons, sources, 3)
+
+	want := []Anomaly{
+		{Key: "", Check: "name", Message: "missing name", Row: "<tr>first</tr>"},
+		{Key: "", Check: "key", Message: "same key as entry 2", Row: "<tr>second</tr>"},
+		{Key: "", Check: "name", Message: "missing name", Row: "<tr>second</tr>"},
+		{Key: "pikachu", Check: "key", Message: "same key as entry 1", Row: "<tr>again</tr>"},
+	}
+	if !reflect.DeepEqual(report.Anomalies, want) {
+		t.Errorf("anomalies:\n got %+v\nwant %+v", report.Anomalies, want)
+	}
+	if report.Checked != 4 || report.Passed {
+		t.Errorf("checked %d, passed %v; want 4 entries and a failed report", report.Checked, report.Passed)
+	}
+
+	if unique := uniquePokedex(pokemons); len(unique) != 2 {
+		t.Errorf("uniquePokedex kept %d entries, want 2", len(unique))
+	}
+}
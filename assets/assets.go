@@ -0,0 +1,108 @@
+// Package assets keeps downloaded artwork in a directory. Files are named
+// by the hash of their contents, so an image shared by several entries is
+// stored once, and manifest.json maps entry keys to files.
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// ManifestFile is the name of the manifest within an assets directory.
+const ManifestFile = "manifest.json"
+
+// Asset is one entry's artwork.
+type Asset struct {
+	File string `json:"file"` // name within the directory, <sha256><ext>
+	URL  string `json:"url"`  // where it was downloaded from
+}
+
+// Manifest maps entry keys (see pokemon.Key) to their artwork.
+type Manifest map[string]Asset
+
+// Load reads the manifest of dir. A directory without one gives an empty
+// manifest.
+func Load(dir string) (Manifest, error) {
+	m := make(Manifest)
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+	return m, nil
+}
+
+// Save writes m as the manifest of dir.
+func (m Manifest) Save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0o644)
+}
+
+// Lookup finds the artwork of p, falling back to its species' for forms
+// without their own.
+func (m Manifest) Lookup(p pokemon.Pokemon) (Asset, bool) {
+	if a, ok := m[p.Key]; ok {
+		return a, true
+	}
+	a, ok := m[p.SpeciesKey()]
+	return a, ok
+}
+
+// Has reports whether the file of a is in dir.
+func Has(dir string, a Asset) bool {
+	if a.File == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, a.File))
+	return err == nil
+}
+
+// Store writes data into dir under its hash and returns the file name.
+// Data already stored is not written again.
+func Store(dir string, data []byte, ext string) (string, error) {
+	sum := sha256.Sum256(data)
+	name := hex.EncodeToString(sum[:]) + ext
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return name, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return name, os.WriteFile(path, data, 0o644)
+}
+
+// Handler serves the files of dir, manifest included. Hashed files never
+// change, so they may be cached for good; the manifest is revalidated.
+func Handler(dir string) http.Handler {
+	files := http.FileServer(http.Dir(dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// front.html may be opened from disk, on another origin
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if filepath.Base(r.URL.Path) == ManifestFile {
+			w.Header().Set("Cache-Control", "no-cache")
+		} else {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		}
+		files.ServeHTTP(w, r)
+	})
+}
@@ -2,9 +2,15 @@ module github.com/thanhduy1706/PokeDBC
 
 go 1.23.1
 
-require github.com/PuerkitoBio/goquery v1.10.0
+require (
+	github.com/PuerkitoBio/goquery v1.10.0
+	go.etcd.io/bbolt v1.4.3
+	google.golang.org/protobuf v1.36.11
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/andybalholm/cascadia v1.3.2 // indirect
 	golang.org/x/net v0.29.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
 )
@@ -0,0 +1,54 @@
+// Package locale translates what the servers tell players. Messages are
+// looked up by ID in a catalog per language; Pokémon names come from the
+// pokemon package (see pokemon.Pokemon.LocalName).
+package locale
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	English    = "en"
+	Vietnamese = "vi"
+
+	Default = English
+)
+
+// Supported lists the languages with a message catalog.
+var Supported = []string{English, Vietnamese}
+
+// Lookup reduces a language tag, "vi-VN" or $LANG's "vi_VN.UTF-8", to one
+// of Supported.
+func Lookup(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_.;"); i >= 0 {
+		tag = tag[:i]
+	}
+	_, ok := catalogs[tag]
+	return tag, ok
+}
+
+// Parse picks the first supported language of a list such as an
+// Accept-Language header ("vi-VN,vi;q=0.9,en"), or Default.
+func Parse(tags string) string {
+	for _, tag := range strings.Split(tags, ",") {
+		if l, ok := Lookup(tag); ok {
+			return l
+		}
+	}
+	return Default
+}
+
+// Sprintf formats message id in lang, falling back to English for
+// messages not translated yet and to the id itself for unknown ones.
+func Sprintf(lang, id string, args ...interface{}) string {
+	format, ok := catalogs[lang][id]
+	if !ok {
+		format, ok = catalogs[Default][id]
+	}
+	if !ok {
+		format = id
+	}
+	return fmt.Sprintf(format, args...)
+}
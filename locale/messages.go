@@ -0,0 +1,112 @@
+package locale
+
+// catalogs hold the message formats by language and message ID. IDs are
+// grouped by the program that sends them.
+var catalogs = map[string]map[string]string{
+	English: {
+		// PokeBat
+		"message.invalid":     "Unexpected message, want %s. Please try again.",
+		"lobby":               "Hello, %s! Wait for any opponent, or create or join a room.",
+		"lobby.queued":        "Waiting for an opponent...",
+		"lobby.room":          "Waiting for an opponent in room %s...",
+		"room.taken":          "There is a room %s already.",
+		"room.none":           "No one is waiting in room %s.",
+		"matched":             "You are player #%d, playing against %s.",
+		"welcome":             "Welcome, %s! Please select your Pokémon.",
+		"choice.invalid":      "Invalid or already selected Pokémon choice. Please select a different Pokémon.",
+		"choice.ok":           "You chose %s as your Pokémon #%d.",
+		"battle.begin":        "Both players have selected their Pokémon. The battle will begin now!",
+		"turn.choose":         "Turn %d: choose your action!",
+		"action.invalid":      "Invalid action. Please try again.",
+		"move.invalid":        "There is no such move. Please try again.",
+		"move.nopp":           "%s has no PP left. Please choose another move.",
+		"attack.dealt":        "%s used %s and dealt %d damage to %s. Remaining HP: %d",
+		"attack.taken":        "The opposing %s used %s and dealt %d damage to your %s. Remaining HP: %d",
+		"attack.missed":       "%s used %s, but it missed!",
+		"attack.status":       "%s used %s, but nothing happened.",
+		"attack.immune":       "%s used %s, but it doesn't affect %s...",
+		"critical":            "A critical hit!",
+		"effective.super":     "It's super effective!",
+		"effective.not":       "It's not very effective...",
+		"fainted":             "%s fainted!",
+		"fainted.opponent":    "The opposing %s fainted!",
+		"win":                 "You win!",
+		"lose":                "You lose!",
+		"switched":            "Switched to %s.",
+		"switched.opponent":   "Your opponent sent out %s.",
+		"switch.none":         "You have no other Pokémon able to battle.",
+		"surrender":           "You surrendered! Game over.",
+		"surrender.opponent":  "Your opponent surrendered! You win!",
+		"disconnect.opponent": "Your opponent left. You win!",
+		"exp.gained":          "%s gained %d experience. Total experience: %d -> %d.",
+		"level.up":            "%s grew to level %d!",
+		"evolved":             "%s evolved into %s!",
+
+		// POKECAT1
+		"player.limit": "Only one player allowed",
+
+		// POKECAT2
+		"inventory.empty": "Your inventory is empty.",
+		"inventory":       "Your Pokemon inventory:",
+		"inventory.item":  "%d: %s (Level %d, %s)",
+		"command.invalid": "Invalid command",
+		"capture.ok":      "You captured a %s at position (%d, %d)!",
+		"capture.full":    "Your Pokemon inventory is full at position (%d, %d)!",
+		"capture.none":    "No Pokemon here at position (%d, %d).",
+		"lang.set":        "Language set to English.",
+		"lang.unknown":    "Unknown language %q, want one of %s",
+	},
+	Vietnamese: {
+		// PokeBat
+		"message.invalid":     "Tin nhắn không đúng, cần %s. Hãy thử lại.",
+		"lobby":               "Xin chào %s! Hãy chờ đối thủ bất kỳ, hoặc tạo hay vào một phòng.",
+		"lobby.queued":        "Đang chờ đối thủ...",
+		"lobby.room":          "Đang chờ đối thủ trong phòng %s...",
+		"room.taken":          "Phòng %s đã có rồi.",
+		"room.none":           "Không có ai đang chờ trong phòng %s.",
+		"matched":             "Bạn là người chơi số %d, đấu với %s.",
+		"welcome":             "Chào mừng %s! Hãy chọn Pokémon của bạn.",
+		"choice.invalid":      "Lựa chọn không hợp lệ hoặc Pokémon đã được chọn. Hãy chọn Pokémon khác.",
+		"choice.ok":           "Bạn đã chọn %s làm Pokémon thứ %d.",
+		"battle.begin":        "Cả hai người chơi đã chọn Pokémon. Trận đấu bắt đầu!",
+		"turn.choose":         "Lượt %d: hãy chọn hành động!",
+		"action.invalid":      "Hành động không hợp lệ. Hãy thử lại.",
+		"move.invalid":        "Không có chiêu thức đó. Hãy thử lại.",
+		"move.nopp":           "%s đã hết PP. Hãy chọn chiêu khác.",
+		"attack.dealt":        "%s dùng %s và gây %d sát thương lên %s. HP còn lại: %d",
+		"attack.taken":        "%s của đối thủ dùng %s và gây %d sát thương lên %s của bạn. HP còn lại: %d",
+		"attack.missed":       "%s dùng %s nhưng trượt!",
+		"attack.status":       "%s dùng %s nhưng không có gì xảy ra.",
+		"attack.immune":       "%s dùng %s nhưng không ảnh hưởng tới %s...",
+		"critical":            "Đòn chí mạng!",
+		"effective.super":     "Rất hiệu quả!",
+		"effective.not":       "Không hiệu quả lắm...",
+		"fainted":             "%s đã gục!",
+		"fainted.opponent":    "%s của đối thủ đã gục!",
+		"win":                 "Bạn thắng!",
+		"lose":                "Bạn thua!",
+		"switched":            "Đã đổi sang %s.",
+		"switched.opponent":   "Đối thủ đưa %s ra sân.",
+		"switch.none":         "Bạn không còn Pokémon nào khác có thể chiến đấu.",
+		"surrender":           "Bạn đã đầu hàng! Trò chơi kết thúc.",
+		"surrender.opponent":  "Đối thủ đã đầu hàng! Bạn thắng!",
+		"disconnect.opponent": "Đối thủ đã rời đi. Bạn thắng!",
+		"exp.gained":          "%s nhận %d kinh nghiệm. Tổng kinh nghiệm: %d -> %d.",
+		"level.up":            "%s đã lên cấp %d!",
+		"evolved":             "%s đã tiến hóa thành %s!",
+
+		// POKECAT1
+		"player.limit": "Chỉ cho phép một người chơi",
+
+		// POKECAT2
+		"inventory.empty": "Túi của bạn đang trống.",
+		"inventory":       "Pokémon của bạn:",
+		"inventory.item":  "%d: %s (Cấp %d, %s)",
+		"command.invalid": "Lệnh không hợp lệ",
+		"capture.ok":      "Bạn đã bắt được %s tại vị trí (%d, %d)!",
+		"capture.full":    "Túi Pokémon của bạn đã đầy tại vị trí (%d, %d)!",
+		"capture.none":    "Không có Pokémon nào tại vị trí (%d, %d).",
+		"lang.set":        "Đã chuyển sang tiếng Việt.",
+		"lang.unknown":    "Không hỗ trợ ngôn ngữ %q, hãy chọn một trong %s",
+	},
+}
@@ -0,0 +1,94 @@
+package locale
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// verb matches the formatting verbs of a message, %% excluded.
+var verb = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+func verbs(format string) []string {
+	var vs []string
+	for _, v := range verb.FindAllString(format, -1) {
+		if v != "%%" {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+func TestCatalogsAreComplete(t *testing.T) {
+	if len(catalogs) != len(Supported) {
+		t.Errorf("%d catalogs for %d supported languages", len(catalogs), len(Supported))
+	}
+	for _, lang := range Supported {
+		catalog, ok := catalogs[lang]
+		if !ok {
+			t.Errorf("no catalog for %s", lang)
+			continue
+		}
+		for _, other := range Supported {
+			for id := range catalogs[other] {
+				if _, ok := catalog[id]; !ok {
+					t.Errorf("%s has no message %q, which %s has", lang, id, other)
+				}
+			}
+		}
+		for id, format := range catalog {
+			if format == "" {
+				t.Errorf("%s message %q is empty", lang, id)
+			}
+			if want := verbs(catalogs[Default][id]); !reflect.DeepEqual(verbs(format), want) {
+				t.Errorf("%s message %q formats %v, %s formats %v", lang, id, verbs(format), Default, want)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{"vi", Vietnamese, true},
+		{"vi-VN", Vietnamese, true},
+		{"vi_VN.UTF-8", Vietnamese, true},
+		{" EN ", English, true},
+		{"fr-FR", "fr", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := Lookup(tt.tag); got != tt.want || ok != tt.ok {
+			t.Errorf("Lookup(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	for tags, want := range map[string]string{
+		"vi-VN,vi;q=0.9,en": Vietnamese,
+		"fr,en-US;q=0.8":    English,
+		"de":                Default,
+		"":                  Default,
+	} {
+		if got := Parse(tags); got != want {
+			t.Errorf("Parse(%q) = %q, want %q", tags, got, want)
+		}
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	if got, want := Sprintf(English, "fainted", "Pikachu"), "Pikachu fainted!"; got != want {
+		t.Errorf("Sprintf = %q, want %q", got, want)
+	}
+	if got, want := Sprintf("fr", "fainted", "Pikachu"), "Pikachu fainted!"; got != want {
+		t.Errorf("Sprintf in an unknown language = %q, want the English %q", got, want)
+	}
+	if got := Sprintf(Vietnamese, "fainted", "Pikachu"); got == Sprintf(English, "fainted", "Pikachu") {
+		t.Errorf("Sprintf in Vietnamese = %q, the English message", got)
+	}
+	if got := Sprintf(English, "no.such.message"); got != "no.such.message" {
+		t.Errorf("Sprintf of an unknown id = %q, want the id", got)
+	}
+}
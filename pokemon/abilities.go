@@ -0,0 +1,75 @@
+package pokemon
+
+import "strings"
+
+// Ability is one of a species' abilities. A species has one or two
+// regular abilities and may have a hidden one, which wild Pokemon don't
+// come with.
+type Ability struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+}
+
+// FindAbility looks up one of p's abilities by name, ignoring case.
+func (p Pokemon) FindAbility(name string) (Ability, bool) {
+	for _, a := range p.Abilities {
+		if strings.EqualFold(a.Name, name) {
+			return a, true
+		}
+	}
+	return Ability{}, false
+}
+
+// rollAbility picks one of p's regular abilities, or none if the species
+// has no abilities listed.
+func (p *Pokemon) rollAbility(intn func(n int) int) {
+	var regular []string
+	for _, a := range p.Abilities {
+		if !a.Hidden {
+			regular = append(regular, a.Name)
+		}
+	}
+	p.Ability = ""
+	if len(regular) > 0 {
+		p.Ability = regular[intn(len(regular))]
+	}
+}
+
+// KeepAbility carries an owned Pokemon's ability over to evolved, its new
+// species: the ability in the same slot of the new species' list, so the
+// second regular ability stays the second and a hidden one stays hidden.
+func (p Pokemon) KeepAbility(evolved *Pokemon) {
+	evolved.Ability = ""
+	if p.Ability == "" {
+		return
+	}
+	slot, hidden := 0, false
+	for _, a := range p.Abilities {
+		if strings.EqualFold(a.Name, p.Ability) {
+			hidden = a.Hidden
+			break
+		}
+		if !a.Hidden {
+			slot++
+		}
+	}
+
+	var regular []string
+	for _, a := range evolved.Abilities {
+		switch {
+		case a.Hidden && hidden:
+			evolved.Ability = a.Name
+			return
+		case !a.Hidden:
+			regular = append(regular, a.Name)
+		}
+	}
+	if len(regular) == 0 {
+		return
+	}
+	if slot >= len(regular) {
+		slot = 0
+	}
+	evolved.Ability = regular[slot]
+}
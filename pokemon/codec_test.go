@@ -0,0 +1,106 @@
+package pokemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// codecPokedex has an entry with every field set, next to a species entry
+// and one with little more than a name.
+var codecPokedex = []Pokemon{
+	{
+		Key: "meowth-alolan", Number: 52, Name: "Meowth", Form: "Alolan Meowth",
+		Names:      Names{"de": "Mauzi", "ja": "ニャース", "x-odd": "a;b=c\\d"},
+		Type:       []string{"Dark"},
+		Total:      290,
+		BaseExp:    58,
+		GrowthRate: MediumFast,
+		Experience: 1000,
+		Level:      10,
+		EVYield:    Stats{Speed: 1},
+		Attributes: Attributes{HP: 40, Attack: 35, Defense: 35, Speed: 90, SpAttack: 50, SpDefense: 40, DmgWhenAtked: 3},
+		IVs:        Stats{HP: 31, Attack: 1, Defense: 2, Speed: 3, SpAttack: 4, SpDefense: 5},
+		EVs:        Stats{Speed: 12, Attack: 4},
+		Nature:     "jolly",
+		Abilities: []Ability{
+			{Name: "Pickup", Description: "The Pokémon may pick up items; or not."},
+			{Name: "Technician", Description: "Powers up weak moves."},
+			{Name: "Rattled", Description: "Fear = speed.", Hidden: true},
+		},
+		Ability: "Technician",
+		Moves: []Move{
+			{Name: "Quick Attack", Type: "Normal", Category: Physical, Power: 40, Accuracy: 100, PP: 30, Priority: 1},
+			{Name: "Bite", Type: "Dark", Category: Physical, Power: 60, Accuracy: 100, PP: 25},
+			{Name: "Growl", Type: "Normal", Category: Status, Accuracy: 100, PP: 40},
+		},
+		ElementalEffects: map[string]float64{"dark": 1.5, "fighting": 0.75},
+		SpawnedAt:        time.Date(2024, 5, 17, 9, 30, 15, 250000000, time.UTC),
+	},
+	{
+		Key: "pikachu", Number: 25, Name: "Pikachu", Type: []string{"Electric"}, Total: 320, BaseExp: 112, Level: 1,
+		Attributes: Attributes{HP: 35, Attack: 55, Defense: 40, Speed: 90, SpAttack: 50, SpDefense: 50},
+	},
+	{Key: "bulbasaur", Name: "Bulbasaur", Type: []string{"Grass", "Poison"}},
+}
+
+func TestRoundTrip(t *testing.T) {
+	codecs := []struct {
+		name  string
+		write func(w io.Writer, pokemons []Pokemon) error
+		read  func(r io.Reader) ([]Pokemon, error)
+	}{
+		{"json", func(w io.Writer, pokemons []Pokemon) error { return json.NewEncoder(w).Encode(pokemons) }, Decode},
+		{"csv", WriteCSV, ReadCSV},
+		{"yaml", WriteYAML, ReadYAML},
+		{"proto", WriteProto, ReadProto},
+	}
+	for _, c := range codecs {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := c.write(&buf, codecPokedex); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			got, err := c.read(&buf)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if len(got) != len(codecPokedex) {
+				t.Fatalf("read %d entries, want %d", len(got), len(codecPokedex))
+			}
+			for i, want := range codecPokedex {
+				if !got[i].SpawnedAt.Equal(want.SpawnedAt) {
+					t.Errorf("%s: spawned_at = %v, want %v", want.Key, got[i].SpawnedAt, want.SpawnedAt)
+				}
+				got[i].SpawnedAt = want.SpawnedAt
+				if !reflect.DeepEqual(got[i], want) {
+					t.Errorf("%s:\n got %+v\nwant %+v", want.Key, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseNames(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Names
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"de=Glurak;fr=Dracaufeu", Names{"de": "Glurak", "fr": "Dracaufeu"}, false},
+		{`x=a\;b\=c\\d`, Names{"x": `a;b=c\d`}, false},
+		{"de=Mauzi=Katze", Names{"de": "Mauzi=Katze"}, false},
+		{"de=Glurak;Dracaufeu", nil, true},
+		{"de=Glurak;", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseNames(tt.in)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseNames(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package pokemon
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// column is one CSV column: its header and how to read and write it.
+type column struct {
+	name string
+	get  func(p *Pokemon) string
+	set  func(p *Pokemon, v string) error
+}
+
+func textColumn(name string, field func(p *Pokemon) *string) column {
+	return column{
+		name: name,
+		get:  func(p *Pokemon) string { return *field(p) },
+		set: func(p *Pokemon, v string) error {
+			*field(p) = v
+			return nil
+		},
+	}
+}
+
+func intColumn(name string, field func(p *Pokemon) *int) column {
+	return column{
+		name: name,
+		get:  func(p *Pokemon) string { return strconv.Itoa(*field(p)) },
+		set: func(p *Pokemon, v string) error {
+			if v == "" {
+				*field(p) = 0
+				return nil
+			}
+			n, err := strconv.Atoi(v)
+			*field(p) = n
+			return err
+		},
+	}
+}
+
+// statsColumns flattens a Stats field into prefix_hp, prefix_attack, ...
+func statsColumns(prefix string, field func(p *Pokemon) *Stats) []column {
+	return []column{
+		intColumn(prefix+"_hp", func(p *Pokemon) *int { return &field(p).HP }),
+		intColumn(prefix+"_attack", func(p *Pokemon) *int { return &field(p).Attack }),
+		intColumn(prefix+"_defense", func(p *Pokemon) *int { return &field(p).Defense }),
+		intColumn(prefix+"_speed", func(p *Pokemon) *int { return &field(p).Speed }),
+		intColumn(prefix+"_sp_attack", func(p *Pokemon) *int { return &field(p).SpAttack }),
+		intColumn(prefix+"_sp_defense", func(p *Pokemon) *int { return &field(p).SpDefense }),
+	}
+}
+
+// csvColumns lay a Pokemon out flat, in the order of its JSON encoding.
+// Types are joined with "/", base stats take the bare stat names, names
+// and elemental effects are written as "de=Glurak;fr=Dracaufeu" and
+// "fire=1.5;water=0.8", a backslash escaping ";", "=" and itself, and
+// abilities and moves, descriptions and all, as JSON lists.
+var csvColumns = func() []column {
+	cols := []column{
+		textColumn("key", func(p *Pokemon) *string { return &p.Key }),
+		intColumn("number", func(p *Pokemon) *int { return &p.Number }),
+		textColumn("name", func(p *Pokemon) *string { return &p.Name }),
+		textColumn("form", func(p *Pokemon) *string { return &p.Form }),
+		{
+			name: "names",
+			get:  func(p *Pokemon) string { return formatNames(p.Names) },
+			set: func(p *Pokemon, v string) (err error) {
+				p.Names, err = parseNames(v)
+				return err
+			},
+		},
+		{
+			name: "type",
+			get:  func(p *Pokemon) string { return strings.Join(p.Type, "/") },
+			set: func(p *Pokemon, v string) error {
+				p.Type = nil
+				if v != "" {
+					p.Type = strings.Split(v, "/")
+				}
+				return nil
+			},
+		},
+		intColumn("total", func(p *Pokemon) *int { return &p.Total }),
+		intColumn("base_exp", func(p *Pokemon) *int { return &p.BaseExp }),
+		textColumn("growth_rate", func(p *Pokemon) *string { return &p.GrowthRate }),
+		intColumn("exp", func(p *Pokemon) *int { return &p.Experience }),
+		intColumn("level", func(p *Pokemon) *int { return &p.Level }),
+	}
+	cols = append(cols, statsColumns("ev_yield", func(p *Pokemon) *Stats { return &p.EVYield })...)
+	cols = append(cols,
+		intColumn("hp", func(p *Pokemon) *int { return &p.Attributes.HP }),
+		intColumn("attack", func(p *Pokemon) *int { return &p.Attributes.Attack }),
+		intColumn("defense", func(p *Pokemon) *int { return &p.Attributes.Defense }),
+		intColumn("speed", func(p *Pokemon) *int { return &p.Attributes.Speed }),
+		intColumn("sp_attack", func(p *Pokemon) *int { return &p.Attributes.SpAttack }),
+		intColumn("sp_defense", func(p *Pokemon) *int { return &p.Attributes.SpDefense }),
+		intColumn("dmg_when_atked", func(p *Pokemon) *int { return &p.Attributes.DmgWhenAtked }),
+	)
+	cols = append(cols, statsColumns("ivs", func(p *Pokemon) *Stats { return &p.IVs })...)
+	cols = append(cols, statsColumns("evs", func(p *Pokemon) *Stats { return &p.EVs })...)
+	return append(cols,
+		textColumn("nature", func(p *Pokemon) *string { return &p.Nature }),
+		column{
+			name: "abilities",
+			get: func(p *Pokemon) string {
+				if len(p.Abilities) == 0 {
+					return ""
+				}
+				data, _ := json.Marshal(p.Abilities)
+				return string(data)
+			},
+			set: func(p *Pokemon, v string) error {
+				p.Abilities = nil
+				if v == "" {
+					return nil
+				}
+				return json.Unmarshal([]byte(v), &p.Abilities)
+			},
+		},
+		textColumn("ability", func(p *Pokemon) *string { return &p.Ability }),
+		column{
+			name: "moves",
+			get: func(p *Pokemon) string {
+				if len(p.Moves) == 0 {
+					return ""
+				}
+				data, _ := json.Marshal(p.Moves)
+				return string(data)
+			},
+			set: func(p *Pokemon, v string) error {
+				p.Moves = nil
+				if v == "" {
+					return nil
+				}
+				return json.Unmarshal([]byte(v), &p.Moves)
+			},
+		},
+		column{
+			name: "elemental_effects",
+			get:  func(p *Pokemon) string { return formatEffects(p.ElementalEffects) },
+			set: func(p *Pokemon, v string) (err error) {
+				p.ElementalEffects, err = parseEffects(v)
+				return err
+			},
+		},
+		column{
+			name: "spawned_at",
+			get: func(p *Pokemon) string {
+				if p.SpawnedAt.IsZero() {
+					return ""
+				}
+				return p.SpawnedAt.Format(time.RFC3339Nano)
+			},
+			set: func(p *Pokemon, v string) (err error) {
+				p.SpawnedAt = time.Time{}
+				if v != "" {
+					p.SpawnedAt, err = time.Parse(time.RFC3339Nano, v)
+				}
+				return err
+			},
+		},
+	)
+}()
+
+// pairEscaper escapes the separators of the "key=value;key=value" lists
+// names and elemental effects are written as.
+var pairEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, "=", `\=`)
+
+func formatPairs(keys []string, value func(key string) string) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = pairEscaper.Replace(k) + "=" + pairEscaper.Replace(value(k))
+	}
+	return strings.Join(parts, ";")
+}
+
+// parsePairs reads what formatPairs writes, calling set for each pair. A
+// part without "=" is an error that want describes.
+func parsePairs(s, want string, set func(key, value string) error) error {
+	var key, value strings.Builder
+	part, inValue := 0, false // part starts at s[part]
+	end := func(i int) error {
+		if !inValue {
+			return fmt.Errorf("%q: want %s", s[part:i], want)
+		}
+		if err := set(key.String(), value.String()); err != nil {
+			return fmt.Errorf("%q: %w", s[part:i], err)
+		}
+		key.Reset()
+		value.Reset()
+		part, inValue = i+1, false
+		return nil
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			c = s[i]
+		case c == '=' && !inValue:
+			inValue = true
+			continue
+		case c == ';':
+			if err := end(i); err != nil {
+				return err
+			}
+			continue
+		}
+		if inValue {
+			value.WriteByte(c)
+		} else {
+			key.WriteByte(c)
+		}
+	}
+	return end(len(s))
+}
+
+func formatEffects(effects map[string]float64) string {
+	return formatPairs(sortedKeys(effects), func(t string) string {
+		return strconv.FormatFloat(effects[t], 'g', -1, 64)
+	})
+}
+
+func formatNames(names Names) string {
+	return formatPairs(sortedKeys(names), func(l string) string { return names[l] })
+}
+
+func parseNames(s string) (Names, error) {
+	if s == "" {
+		return nil, nil
+	}
+	names := make(Names)
+	err := parsePairs(s, "locale=name", func(l, name string) error {
+		names[l] = name
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func parseEffects(s string) (map[string]float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	effects := make(map[string]float64)
+	err := parsePairs(s, "type=multiplier", func(t, v string) (err error) {
+		effects[t], err = strconv.ParseFloat(v, 64)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return effects, nil
+}
+
+// WriteCSV writes pokemons as CSV with a header row.
+func WriteCSV(w io.Writer, pokemons []Pokemon) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(csvColumns))
+	for i, col := range csvColumns {
+		header[i] = col.name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(csvColumns))
+	for i := range pokemons {
+		for j, col := range csvColumns {
+			row[j] = col.get(&pokemons[i])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads what WriteCSV writes. Columns are matched by header, so
+// they may come in any order, and missing ones are left zero.
+func ReadCSV(r io.Reader) ([]Pokemon, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]column, len(csvColumns))
+	for _, col := range csvColumns {
+		byName[col.name] = col
+	}
+	cols := make([]*column, len(header))
+	for i, name := range header {
+		if col, ok := byName[strings.TrimSpace(name)]; ok {
+			cols[i] = &col
+		}
+	}
+
+	var pokemons []Pokemon
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return pokemons, nil
+		} else if err != nil {
+			return pokemons, err
+		}
+
+		var p Pokemon
+		for i, v := range record {
+			if i >= len(cols) || cols[i] == nil {
+				continue
+			}
+			if err := cols[i].set(&p, v); err != nil {
+				return pokemons, fmt.Errorf("line %d, %s: %w", line, cols[i].name, err)
+			}
+		}
+		if p.Key == "" && p.Name != "" {
+			p.Key = Key(p.Name, p.Form)
+		}
+		pokemons = append(pokemons, p)
+	}
+}
@@ -0,0 +1,66 @@
+package pokemon
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// Evolution is one arrow of an evolution chart, as the Pokedex scraper
+// writes them to evolutions.json. From and To are entry keys (see Key).
+// Level is set for level-up evolutions, Item for evolution stones and the
+// like; anything else is kept in Condition.
+type Evolution struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Level     int    `json:"level,omitempty"`
+	Item      string `json:"item,omitempty"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// LoadEvolutions reads evolution charts from a JSON file.
+func LoadEvolutions(name string) ([]Evolution, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var evolutions []Evolution
+	err = json.Unmarshal(data, &evolutions)
+	return evolutions, err
+}
+
+// Evolve returns what p evolves into at its current Level, after LevelUp,
+// or, with item set, when item is used on it; ok is false if none of
+// evolutions applies. Evolutions that need anything else (friendship, a
+// time of day, a trade, ...) are never triggered here.
+//
+// The evolved Pokemon is the new species from pokedex that keeps p's
+// exp, level, IVs, EVs, nature, ability slot and moves, and stats are
+// its CalcStats at that level.
+func (p Pokemon) Evolve(pokedex []Pokemon, evolutions []Evolution, item string) (evolved Pokemon, stats Attributes, ok bool) {
+	for _, e := range evolutions {
+		if e.From != p.Key || e.Condition != "" || e.Level > p.Level || !strings.EqualFold(e.Item, item) {
+			continue
+		}
+		if e.Level == 0 && e.Item == "" {
+			continue
+		}
+		for _, species := range pokedex {
+			if species.Key != e.To {
+				continue
+			}
+			evolved = species
+			evolved.Experience = p.Experience
+			evolved.Level = p.Level
+			evolved.IVs = p.IVs
+			evolved.EVs = p.EVs
+			evolved.Nature = p.Nature
+			evolved.Moves = p.Moves
+			evolved.ElementalEffects = p.ElementalEffects
+			evolved.SpawnedAt = p.SpawnedAt
+			p.KeepAbility(&evolved)
+			return evolved, evolved.CalcStats(), true
+		}
+	}
+	return p, p.CalcStats(), false
+}
@@ -0,0 +1,98 @@
+package pokemon
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	evoPokedex = []Pokemon{
+		{Key: "pichu", Name: "Pichu", Attributes: Attributes{HP: 20, Attack: 40, Defense: 15, Speed: 60, SpAttack: 35, SpDefense: 35}},
+		{Key: "pikachu", Name: "Pikachu", Attributes: Attributes{HP: 35, Attack: 55, Defense: 40, Speed: 90, SpAttack: 50, SpDefense: 50}},
+		{Key: "raichu", Name: "Raichu", Attributes: Attributes{HP: 60, Attack: 90, Defense: 55, Speed: 110, SpAttack: 90, SpDefense: 80}},
+		{Key: "charmander", Name: "Charmander", Abilities: []Ability{{Name: "Blaze"}, {Name: "Solar Power", Hidden: true}}},
+		{Key: "charmeleon", Name: "Charmeleon", Abilities: []Ability{{Name: "Blaze"}, {Name: "Solar Power", Hidden: true}},
+			Attributes: Attributes{HP: 58, Attack: 64, Defense: 58, Speed: 80, SpAttack: 80, SpDefense: 65}},
+		{Key: "eevee", Name: "Eevee"},
+		{Key: "umbreon", Name: "Umbreon"},
+	}
+	evoChart = []Evolution{
+		{From: "pichu", To: "pikachu", Condition: "high Friendship"},
+		{From: "pikachu", To: "raichu", Item: "Thunder Stone"},
+		{From: "charmander", To: "charmeleon", Level: 16},
+		{From: "eevee", To: "umbreon", Level: 20, Condition: "at night"},
+	}
+)
+
+func TestEvolve(t *testing.T) {
+	owned := Pokemon{
+		Key: "charmander", Name: "Charmander", Level: 16, Experience: 4096,
+		IVs: Stats{HP: 31, Attack: 20}, EVs: Stats{Speed: 8}, Nature: "timid",
+		Abilities: []Ability{{Name: "Blaze"}, {Name: "Solar Power", Hidden: true}}, Ability: "Solar Power",
+		Moves: []Move{{Name: "Ember", Type: "Fire", Power: 40, PP: 25}},
+	}
+
+	tests := []struct {
+		name string
+		p    Pokemon
+		item string
+		to   string // "" if p doesn't evolve
+	}{
+		{"level reached", owned, "", "charmeleon"},
+		{"level too low", func() Pokemon { p := owned; p.Level = 15; return p }(), "", ""},
+		{"item", Pokemon{Key: "pikachu", Level: 5}, "thunder stone", "raichu"},
+		{"item missing", Pokemon{Key: "pikachu", Level: 100}, "", ""},
+		{"wrong item", Pokemon{Key: "pikachu", Level: 5}, "Fire Stone", ""},
+		{"item on level evolution", owned, "Thunder Stone", ""},
+		{"condition", Pokemon{Key: "pichu", Level: 100}, "", ""},
+		{"level and condition", Pokemon{Key: "eevee", Level: 20}, "", ""},
+		{"no evolution", Pokemon{Key: "raichu", Level: 100}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evolved, stats, ok := tt.p.Evolve(evoPokedex, evoChart, tt.item)
+			if ok != (tt.to != "") {
+				t.Fatalf("ok = %v, want %v", ok, tt.to != "")
+			}
+			if !ok {
+				if !reflect.DeepEqual(evolved, tt.p) || stats != tt.p.CalcStats() {
+					t.Errorf("Evolve changed a Pokemon that doesn't evolve: %+v", evolved)
+				}
+				return
+			}
+			if evolved.Key != tt.to {
+				t.Errorf("evolved into %s, want %s", evolved.Key, tt.to)
+			}
+			if stats != evolved.CalcStats() {
+				t.Errorf("stats = %+v, want %+v", stats, evolved.CalcStats())
+			}
+		})
+	}
+}
+
+func TestEvolveKeepsIndividual(t *testing.T) {
+	owned := Pokemon{
+		Key: "charmander", Name: "Charmander", Level: 18, Experience: 5832,
+		IVs: Stats{HP: 31, Attack: 20}, EVs: Stats{Speed: 8}, Nature: "timid",
+		Abilities: []Ability{{Name: "Blaze"}, {Name: "Solar Power", Hidden: true}}, Ability: "Solar Power",
+		Moves: []Move{{Name: "Ember", Type: "Fire", Power: 40, PP: 25}},
+	}
+	evolved, stats, ok := owned.Evolve(evoPokedex, evoChart, "")
+	if !ok {
+		t.Fatal("charmander didn't evolve at level 18")
+	}
+
+	want := evoPokedex[4]
+	want.Level, want.Experience = owned.Level, owned.Experience
+	want.IVs, want.EVs, want.Nature = owned.IVs, owned.EVs, owned.Nature
+	want.Ability, want.Moves = "Solar Power", owned.Moves
+	if !reflect.DeepEqual(evolved, want) {
+		t.Errorf("got  %+v\nwant %+v", evolved, want)
+	}
+
+	// Charmeleon's base stats at level 18, IVs, EVs and a timid nature
+	wantStats := Attributes{HP: 54, Attack: 27, Defense: 25, Speed: 37, SpAttack: 33, SpDefense: 28}
+	if stats != wantStats {
+		t.Errorf("stats = %+v, want %+v", stats, wantStats)
+	}
+}
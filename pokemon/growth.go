@@ -0,0 +1,80 @@
+package pokemon
+
+// Experience groups, named as pokemondb's "Growth Rate" row with spaces
+// replaced by dashes.
+const (
+	Erratic     = "erratic"
+	Fast        = "fast"
+	MediumFast  = "medium-fast"
+	MediumSlow  = "medium-slow"
+	Slow        = "slow"
+	Fluctuating = "fluctuating"
+
+	MaxLevel = 100
+)
+
+// ExpForLevel is the total experience a Pokemon of the given growth rate
+// needs to reach level n. Unknown rates use medium-fast.
+func ExpForLevel(rate string, n int) int {
+	if n <= 1 {
+		return 0
+	}
+	if n > MaxLevel {
+		n = MaxLevel
+	}
+
+	cube := n * n * n
+	switch rate {
+	case Erratic:
+		switch {
+		case n <= 50:
+			return cube * (100 - n) / 50
+		case n <= 68:
+			return cube * (150 - n) / 100
+		case n <= 98:
+			return cube * ((1911 - 10*n) / 3) / 500
+		default:
+			return cube * (160 - n) / 100
+		}
+	case Fast:
+		return 4 * cube / 5
+	case MediumSlow:
+		return 6*cube/5 - 15*n*n + 100*n - 140
+	case Slow:
+		return 5 * cube / 4
+	case Fluctuating:
+		switch {
+		case n <= 15:
+			return cube * ((n+1)/3 + 24) / 50
+		case n <= 36:
+			return cube * (n + 14) / 50
+		default:
+			return cube * (n/2 + 32) / 50
+		}
+	default:
+		return cube
+	}
+}
+
+// LevelForExp is the highest level whose experience requirement exp meets.
+func LevelForExp(rate string, exp int) int {
+	level := 1
+	for level < MaxLevel && ExpForLevel(rate, level+1) <= exp {
+		level++
+	}
+	return level
+}
+
+// LevelUp raises Level to whatever the Pokemon's total Experience reaches
+// on its growth rate curve, possibly several levels at once, and returns
+// the number of levels gained. Stats follow the new level through
+// CalcStats.
+func (p *Pokemon) LevelUp() int {
+	level := LevelForExp(p.GrowthRate, p.Experience)
+	gained := level - p.Level
+	if gained <= 0 {
+		return 0
+	}
+	p.Level = level
+	return gained
+}
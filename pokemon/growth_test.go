@@ -0,0 +1,92 @@
+package pokemon
+
+import "testing"
+
+// Experience totals from Bulbapedia's experience tables, at the first and
+// last level of each piece of the erratic and fluctuating curves.
+func TestExpForLevel(t *testing.T) {
+	tests := []struct {
+		rate  string
+		level int
+		want  int
+	}{
+		{Erratic, 1, 0},
+		{Erratic, 2, 15},
+		{Erratic, 50, 125000},
+		{Erratic, 51, 131324},
+		{Erratic, 68, 257834},
+		{Erratic, 69, 267406},
+		{Erratic, 98, 583539},
+		{Erratic, 99, 591882},
+		{Erratic, 100, 600000},
+		{Fast, 2, 6},
+		{Fast, 50, 100000},
+		{Fast, 100, 800000},
+		{MediumFast, 2, 8},
+		{MediumFast, 50, 125000},
+		{MediumFast, 100, 1000000},
+		{MediumSlow, 2, 9},
+		{MediumSlow, 50, 117360},
+		{MediumSlow, 100, 1059860},
+		{Slow, 2, 10},
+		{Slow, 50, 156250},
+		{Slow, 100, 1250000},
+		{Fluctuating, 2, 4},
+		{Fluctuating, 15, 1957},
+		{Fluctuating, 16, 2457},
+		{Fluctuating, 36, 46656},
+		{Fluctuating, 37, 50653},
+		{Fluctuating, 50, 142500},
+		{Fluctuating, 98, 1524731},
+		{Fluctuating, 100, 1640000},
+		{"", 50, 125000},
+		{Slow, 0, 0},
+		{Slow, 101, 1250000},
+	}
+	for _, tt := range tests {
+		if got := ExpForLevel(tt.rate, tt.level); got != tt.want {
+			t.Errorf("ExpForLevel(%q, %d) = %d, want %d", tt.rate, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestLevelForExp(t *testing.T) {
+	for _, rate := range []string{Erratic, Fast, MediumFast, MediumSlow, Slow, Fluctuating} {
+		for level := 2; level <= MaxLevel; level++ {
+			exp := ExpForLevel(rate, level)
+			if exp <= ExpForLevel(rate, level-1) {
+				t.Errorf("%s: level %d needs %d exp, no more than level %d", rate, level, exp, level-1)
+			}
+			if got := LevelForExp(rate, exp); got != level {
+				t.Errorf("LevelForExp(%q, %d) = %d, want %d", rate, exp, got, level)
+			}
+			if got := LevelForExp(rate, exp-1); got != level-1 {
+				t.Errorf("LevelForExp(%q, %d) = %d, want %d", rate, exp-1, got, level-1)
+			}
+		}
+		if got := LevelForExp(rate, 1<<30); got != MaxLevel {
+			t.Errorf("LevelForExp(%q, lots) = %d, want %d", rate, got, MaxLevel)
+		}
+	}
+}
+
+func TestLevelUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		p         Pokemon
+		gained    int
+		wantLevel int
+	}{
+		{"one level", Pokemon{GrowthRate: MediumFast, Level: 4, Experience: 125}, 1, 5},
+		{"several levels", Pokemon{GrowthRate: Erratic, Level: 50, Experience: 267406}, 19, 69},
+		{"short of the next", Pokemon{GrowthRate: Slow, Level: 50, Experience: 156250 + 1000}, 0, 50},
+		{"below its level", Pokemon{GrowthRate: Fast, Level: 20, Experience: 9}, 0, 20},
+		{"capped", Pokemon{GrowthRate: Fluctuating, Level: 99, Experience: 2000000}, 1, 100},
+	}
+	for _, tt := range tests {
+		p := tt.p
+		if gained := p.LevelUp(); gained != tt.gained || p.Level != tt.wantLevel {
+			t.Errorf("%s: LevelUp() = %d, level %d; want %d, level %d", tt.name, gained, p.Level, tt.gained, tt.wantLevel)
+		}
+	}
+}
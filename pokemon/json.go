@@ -0,0 +1,139 @@
+package pokemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// legacyFields are the capitalized, untagged fields of the Pokemon structs
+// PokeBat (pokedex_player*.json) and POKECAT1 (player_pokemon.json) wrote
+// before this package. Their float EV bonus and IsFainted flag have no
+// counterpart here and are dropped.
+type legacyFields struct {
+	HP               int                `json:"HP"`
+	Attack           int                `json:"Attack"`
+	Defense          int                `json:"Defense"`
+	SpecialAttack    int                `json:"SpecialAttack"`
+	SpecialDefense   int                `json:"SpecialDefense"`
+	Speed            int                `json:"Speed"`
+	ElementalEffects map[string]float64 `json:"ElementalEffects"`
+	Experience       int                `json:"Experience"`
+	SpawnedAt        time.Time          `json:"SpawnedAt"`
+}
+
+// MarshalJSON leaves spawned_at out for Pokemon that were never spawned,
+// which encoding/json's omitempty can't do for a time.Time.
+func (p Pokemon) MarshalJSON() ([]byte, error) {
+	type plain Pokemon
+	w := struct {
+		plain
+		SpawnedAt *time.Time `json:"spawned_at,omitempty"`
+	}{plain: plain(p)}
+	if !p.SpawnedAt.IsZero() {
+		w.SpawnedAt = &p.SpawnedAt
+	}
+	return json.Marshal(w)
+}
+
+// UnmarshalJSON reads the current snake_case shape as well as the legacy
+// ones: top-level stats, Experience and SpawnedAt fill Attributes,
+// Experience and SpawnedAt when those are missing, and entries without a
+// key (pokedex.json before keys, POKECAT1's pokemon.json) get one from
+// their name and form.
+func (p *Pokemon) UnmarshalJSON(data []byte) error {
+	type plain Pokemon
+	var w struct {
+		plain
+		legacyFields
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+
+	*p = Pokemon(w.plain)
+	old := w.legacyFields
+	if p.Attributes == (Attributes{}) {
+		p.Attributes = Attributes{
+			HP:        old.HP,
+			Attack:    old.Attack,
+			Defense:   old.Defense,
+			Speed:     old.Speed,
+			SpAttack:  old.SpecialAttack,
+			SpDefense: old.SpecialDefense,
+		}
+	}
+	if p.Experience == 0 {
+		p.Experience = old.Experience
+	}
+	if p.ElementalEffects == nil {
+		p.ElementalEffects = old.ElementalEffects
+	}
+	if p.SpawnedAt.IsZero() {
+		p.SpawnedAt = old.SpawnedAt
+	}
+	if p.Key == "" && p.Name != "" {
+		p.Key = Key(p.Name, p.Form)
+	}
+	return nil
+}
+
+// Decode reads every Pokemon in r. r may hold a JSON array (pokedex.json,
+// PokeBat's team files), a Pokedex object, or a stream of values one after
+// another (player_pokemon.json has one object per line).
+func Decode(r io.Reader) ([]Pokemon, error) {
+	dec := json.NewDecoder(r)
+	var pokemons []Pokemon
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			return pokemons, nil
+		} else if err != nil {
+			return pokemons, err
+		}
+
+		switch raw[0] {
+		case '[':
+			var batch []Pokemon
+			if err := json.Unmarshal(raw, &batch); err != nil {
+				return pokemons, err
+			}
+			pokemons = append(pokemons, batch...)
+		case '{':
+			var dex struct {
+				Pokemons *[]Pokemon `json:"pokemons"`
+			}
+			if err := json.Unmarshal(raw, &dex); err != nil {
+				return pokemons, err
+			}
+			if dex.Pokemons != nil {
+				pokemons = append(pokemons, *dex.Pokemons...)
+				continue
+			}
+			var p Pokemon
+			if err := json.Unmarshal(raw, &p); err != nil {
+				return pokemons, err
+			}
+			pokemons = append(pokemons, p)
+		default:
+			return pokemons, fmt.Errorf("expected a Pokemon, a list or a Pokedex, got %.20s", raw)
+		}
+	}
+}
+
+// Load reads a file in any of the shapes Decode accepts.
+func Load(name string) ([]Pokemon, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pokemons, err := Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", name, err)
+	}
+	return pokemons, nil
+}
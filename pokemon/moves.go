@@ -0,0 +1,27 @@
+package pokemon
+
+// MaxMoves is how many moves a Pokemon knows at most.
+const MaxMoves = 4
+
+// Move categories.
+const (
+	Physical = "physical" // hits Attack against Defense
+	Special  = "special"  // hits Sp. Attack against Sp. Defense
+	Status   = "status"   // does no damage
+)
+
+// Move is an entry of pokemondb's move list, or one of the moves an owned
+// Pokemon knows. Power and Accuracy are 0 for moves that have none (status
+// moves, moves that never miss); PP is how often it can be used in a
+// battle. Moves of higher Priority go before others in a turn, whatever
+// the Pokemon's Speed; most moves have 0, Quick Attack has 1.
+type Move struct {
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Category string `json:"category" yaml:"category"`
+	Power    int    `json:"power" yaml:"power"`
+	Accuracy int    `json:"accuracy" yaml:"accuracy"`
+	PP       int    `json:"pp" yaml:"pp"`
+	Priority int    `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Effect   string `json:"effect,omitempty" yaml:"effect,omitempty"`
+}
@@ -0,0 +1,67 @@
+package pokemon
+
+import (
+	"sort"
+	"strings"
+)
+
+// Names are a species' names in other languages, by locale: "ja", "de",
+// "fr", "zh-Hans", ... Name stays the English one.
+type Names map[string]string
+
+// LocalName is p's name in locale, or in its language when there is none
+// for the region ("zh-Hant-TW" -> "zh-Hant" -> "zh"), or else the English
+// name. The Vietnamese games use the English names, so "vi" gives Name
+// unless Names has been given one.
+func (p Pokemon) LocalName(locale string) string {
+	for locale != "" {
+		if name, ok := p.Names[locale]; ok && name != "" {
+			return name
+		}
+		i := strings.LastIndexAny(locale, "-_")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return p.Name
+}
+
+// HasName reports whether name is p's English name or its name in
+// locale, ignoring case. An empty locale matches the name in any.
+func (p Pokemon) HasName(name, locale string) bool {
+	if strings.EqualFold(p.Name, name) {
+		return true
+	}
+	if locale != "" {
+		return strings.EqualFold(p.LocalName(locale), name)
+	}
+	for _, n := range p.Names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllNames lists the English name first and then the others, each once.
+func (p Pokemon) AllNames() []string {
+	names := []string{p.Name}
+	seen := map[string]bool{p.Name: true}
+	for _, locale := range sortedKeys(p.Names) {
+		if n := p.Names[locale]; n != "" && !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
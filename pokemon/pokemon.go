@@ -0,0 +1,150 @@
+// Package pokemon holds the Pokemon record shared by the Pokedex scraper,
+// PokeBat and the POKECAT world servers, and the rules that work on it:
+// stat calculation, natures, effort values, experience curves and
+// evolution.
+//
+// A Pokemon is both a species entry of pokedex.json (Key, Number, Type,
+// base stats in Attributes, ...) and an individual a player owns, once
+// its Level, IVs, EVs, Nature, Ability and Moves are set. Load reads
+// pokedex.json as well as the older files written by the game servers;
+// see UnmarshalJSON.
+package pokemon
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Attributes are a species' base stats. Use CalcStats for the stats of an
+// individual at its level.
+type Attributes struct {
+	HP           int `json:"hp" yaml:"hp"`
+	Attack       int `json:"attack" yaml:"attack"`
+	Defense      int `json:"defense" yaml:"defense"`
+	Speed        int `json:"speed" yaml:"speed"`
+	SpAttack     int `json:"sp_attack" yaml:"sp_attack"`
+	SpDefense    int `json:"sp_defense" yaml:"sp_defense"`
+	DmgWhenAtked int `json:"dmg_when_atked" yaml:"dmg_when_atked"`
+}
+
+// Stats is one value per stat: the effort values a Pokemon gives when
+// defeated (EVYield), or an owned Pokemon's individual and effort values.
+type Stats struct {
+	HP        int `json:"hp" yaml:"hp"`
+	Attack    int `json:"attack" yaml:"attack"`
+	Defense   int `json:"defense" yaml:"defense"`
+	Speed     int `json:"speed" yaml:"speed"`
+	SpAttack  int `json:"sp_attack" yaml:"sp_attack"`
+	SpDefense int `json:"sp_defense" yaml:"sp_defense"`
+}
+
+type Pokemon struct {
+	Key        string     `json:"key" yaml:"key"`
+	Number     int        `json:"number" yaml:"number"`
+	Name       string     `json:"name" yaml:"name"`
+	Form       string     `json:"form,omitempty" yaml:"form,omitempty"`
+	Names      Names      `json:"names,omitempty" yaml:"names,omitempty"` // see LocalName
+	Type       []string   `json:"type" yaml:"type"`
+	Total      int        `json:"total" yaml:"total"`
+	BaseExp    int        `json:"base_exp" yaml:"base_exp"`
+	GrowthRate string     `json:"growth_rate,omitempty" yaml:"growth_rate,omitempty"`
+	Experience int        `json:"exp" yaml:"exp"` // total experience, see ExpForLevel
+	Level      int        `json:"level" yaml:"level"`
+	EVYield    Stats      `json:"ev_yield" yaml:"ev_yield"`
+	Attributes Attributes `json:"attributes" yaml:"attributes"` // base stats, see CalcStats
+	IVs        Stats      `json:"ivs" yaml:"ivs"`
+	EVs        Stats      `json:"evs" yaml:"evs"`
+	Nature     string     `json:"nature,omitempty" yaml:"nature,omitempty"`
+	Abilities  []Ability  `json:"abilities,omitempty" yaml:"abilities,omitempty"`
+	Ability    string     `json:"ability,omitempty" yaml:"ability,omitempty"` // an owned Pokemon's, one of Abilities
+	Moves      []Move     `json:"moves,omitempty" yaml:"moves,omitempty"`     // an owned Pokemon's, at most MaxMoves
+
+	// Per-type damage multipliers carried over from PokeBat's team files,
+	// for this Pokemon's moves of a type; PokeBat uses them in place of
+	// the same-type attack bonus
+	ElementalEffects map[string]float64 `json:"elemental_effects,omitempty" yaml:"elemental_effects,omitempty"`
+
+	// When a world server put this Pokemon on the map; zero for species
+	// entries and is left out of the JSON then
+	SpawnedAt time.Time `json:"spawned_at" yaml:"spawned_at,omitempty"`
+}
+
+type Pokedex struct {
+	Pokemons []Pokemon `json:"pokemons" yaml:"pokemons"`
+}
+
+// Key identifies one entry of the dex, e.g. "charizard-mega-x" or
+// "meowth-galarian" for the forms and "pikachu" for the base species.
+func Key(name, form string) string {
+	key := Slugify(name)
+	if f := formKey(name, form); f != "" {
+		key += "-" + f
+	}
+	return key
+}
+
+// formKey reduces a form label to the words that identify it, so that
+// "Alolan Raichu" (pokemondb) and "Alolan Form" (Bulbapedia) agree.
+func formKey(name, form string) string {
+	form = strings.Replace(strings.ToLower(form), strings.ToLower(name), "", 1)
+	var words []string
+	for _, w := range strings.Fields(form) {
+		if w == "form" || w == "forme" {
+			continue
+		}
+		words = append(words, w)
+	}
+	return Slugify(strings.Join(words, " "))
+}
+
+var genderSigns = strings.NewReplacer("♀", "-f", "♂", "-m")
+
+// Slugify lowercases s and joins its words with dashes, the way pokemondb
+// names its pages ("Mr. Mime" -> "mr-mime", "Nidoran♀" -> "nidoran-f").
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range genderSigns.Replace(strings.ToLower(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// SpeciesKey is the key of the base form, shared by all forms of a species.
+func (p Pokemon) SpeciesKey() string {
+	return Key(p.Name, "")
+}
+
+// StatNames are the names Stat accepts, as in the Attributes json tags,
+// plus the base stat total.
+var StatNames = []string{"hp", "attack", "defense", "sp_attack", "sp_defense", "speed", "total"}
+
+// Stat returns the base stat called name (see StatNames).
+func (p Pokemon) Stat(name string) (int, bool) {
+	switch strings.ToLower(name) {
+	case "hp":
+		return p.Attributes.HP, true
+	case "attack":
+		return p.Attributes.Attack, true
+	case "defense":
+		return p.Attributes.Defense, true
+	case "sp_attack":
+		return p.Attributes.SpAttack, true
+	case "sp_defense":
+		return p.Attributes.SpDefense, true
+	case "speed":
+		return p.Attributes.Speed, true
+	case "total":
+		return p.Total, true
+	}
+	return 0, false
+}
@@ -0,0 +1,6 @@
+// Package pokemonpb holds the protobuf types generated from pokemon.proto.
+// Convert to and from the pokemon package's types with pokemon.ToProto and
+// pokemon.FromProto.
+package pokemonpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative pokemon.proto
@@ -0,0 +1,751 @@
+// Protobuf form of the pokemon package's records, for compact network
+// messages. Field names follow the JSON encoding of pokedex.json.
+//
+// After editing, run go generate in this directory to rebuild pokemon.pb.go.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pokemon.proto
+
+package pokemonpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Stats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hp            int32                  `protobuf:"varint,1,opt,name=hp,proto3" json:"hp,omitempty"`
+	Attack        int32                  `protobuf:"varint,2,opt,name=attack,proto3" json:"attack,omitempty"`
+	Defense       int32                  `protobuf:"varint,3,opt,name=defense,proto3" json:"defense,omitempty"`
+	Speed         int32                  `protobuf:"varint,4,opt,name=speed,proto3" json:"speed,omitempty"`
+	SpAttack      int32                  `protobuf:"varint,5,opt,name=sp_attack,json=spAttack,proto3" json:"sp_attack,omitempty"`
+	SpDefense     int32                  `protobuf:"varint,6,opt,name=sp_defense,json=spDefense,proto3" json:"sp_defense,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_pokemon_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_pokemon_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Stats) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *Stats) GetAttack() int32 {
+	if x != nil {
+		return x.Attack
+	}
+	return 0
+}
+
+func (x *Stats) GetDefense() int32 {
+	if x != nil {
+		return x.Defense
+	}
+	return 0
+}
+
+func (x *Stats) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Stats) GetSpAttack() int32 {
+	if x != nil {
+		return x.SpAttack
+	}
+	return 0
+}
+
+func (x *Stats) GetSpDefense() int32 {
+	if x != nil {
+		return x.SpDefense
+	}
+	return 0
+}
+
+type Attributes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hp            int32                  `protobuf:"varint,1,opt,name=hp,proto3" json:"hp,omitempty"`
+	Attack        int32                  `protobuf:"varint,2,opt,name=attack,proto3" json:"attack,omitempty"`
+	Defense       int32                  `protobuf:"varint,3,opt,name=defense,proto3" json:"defense,omitempty"`
+	Speed         int32                  `protobuf:"varint,4,opt,name=speed,proto3" json:"speed,omitempty"`
+	SpAttack      int32                  `protobuf:"varint,5,opt,name=sp_attack,json=spAttack,proto3" json:"sp_attack,omitempty"`
+	SpDefense     int32                  `protobuf:"varint,6,opt,name=sp_defense,json=spDefense,proto3" json:"sp_defense,omitempty"`
+	DmgWhenAtked  int32                  `protobuf:"varint,7,opt,name=dmg_when_atked,json=dmgWhenAtked,proto3" json:"dmg_when_atked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attributes) Reset() {
+	*x = Attributes{}
+	mi := &file_pokemon_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attributes) ProtoMessage() {}
+
+func (x *Attributes) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attributes.ProtoReflect.Descriptor instead.
+func (*Attributes) Descriptor() ([]byte, []int) {
+	return file_pokemon_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Attributes) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *Attributes) GetAttack() int32 {
+	if x != nil {
+		return x.Attack
+	}
+	return 0
+}
+
+func (x *Attributes) GetDefense() int32 {
+	if x != nil {
+		return x.Defense
+	}
+	return 0
+}
+
+func (x *Attributes) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Attributes) GetSpAttack() int32 {
+	if x != nil {
+		return x.SpAttack
+	}
+	return 0
+}
+
+func (x *Attributes) GetSpDefense() int32 {
+	if x != nil {
+		return x.SpDefense
+	}
+	return 0
+}
+
+func (x *Attributes) GetDmgWhenAtked() int32 {
+	if x != nil {
+		return x.DmgWhenAtked
+	}
+	return 0
+}
+
+type Ability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Hidden        bool                   `protobuf:"varint,3,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ability) Reset() {
+	*x = Ability{}
+	mi := &file_pokemon_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ability) ProtoMessage() {}
+
+func (x *Ability) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ability.ProtoReflect.Descriptor instead.
+func (*Ability) Descriptor() ([]byte, []int) {
+	return file_pokemon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Ability) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Ability) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Ability) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+type Move struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Power         int32                  `protobuf:"varint,4,opt,name=power,proto3" json:"power,omitempty"`
+	Accuracy      int32                  `protobuf:"varint,5,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	Pp            int32                  `protobuf:"varint,6,opt,name=pp,proto3" json:"pp,omitempty"`
+	Effect        string                 `protobuf:"bytes,7,opt,name=effect,proto3" json:"effect,omitempty"`
+	Priority      int32                  `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Move) Reset() {
+	*x = Move{}
+	mi := &file_pokemon_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Move) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Move) ProtoMessage() {}
+
+func (x *Move) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Move.ProtoReflect.Descriptor instead.
+func (*Move) Descriptor() ([]byte, []int) {
+	return file_pokemon_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Move) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Move) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Move) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Move) GetPower() int32 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
+func (x *Move) GetAccuracy() int32 {
+	if x != nil {
+		return x.Accuracy
+	}
+	return 0
+}
+
+func (x *Move) GetPp() int32 {
+	if x != nil {
+		return x.Pp
+	}
+	return 0
+}
+
+func (x *Move) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+func (x *Move) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type Pokemon struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Key              string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Number           int32                  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Name             string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Form             string                 `protobuf:"bytes,4,opt,name=form,proto3" json:"form,omitempty"`
+	Names            map[string]string      `protobuf:"bytes,18,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Type             []string               `protobuf:"bytes,5,rep,name=type,proto3" json:"type,omitempty"`
+	Total            int32                  `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	BaseExp          int32                  `protobuf:"varint,7,opt,name=base_exp,json=baseExp,proto3" json:"base_exp,omitempty"`
+	GrowthRate       string                 `protobuf:"bytes,8,opt,name=growth_rate,json=growthRate,proto3" json:"growth_rate,omitempty"`
+	Exp              int32                  `protobuf:"varint,9,opt,name=exp,proto3" json:"exp,omitempty"`
+	Level            int32                  `protobuf:"varint,10,opt,name=level,proto3" json:"level,omitempty"`
+	EvYield          *Stats                 `protobuf:"bytes,11,opt,name=ev_yield,json=evYield,proto3" json:"ev_yield,omitempty"`
+	Attributes       *Attributes            `protobuf:"bytes,12,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	Ivs              *Stats                 `protobuf:"bytes,13,opt,name=ivs,proto3" json:"ivs,omitempty"`
+	Evs              *Stats                 `protobuf:"bytes,14,opt,name=evs,proto3" json:"evs,omitempty"`
+	Nature           string                 `protobuf:"bytes,15,opt,name=nature,proto3" json:"nature,omitempty"`
+	Abilities        []*Ability             `protobuf:"bytes,19,rep,name=abilities,proto3" json:"abilities,omitempty"`
+	Ability          string                 `protobuf:"bytes,20,opt,name=ability,proto3" json:"ability,omitempty"`
+	Moves            []*Move                `protobuf:"bytes,21,rep,name=moves,proto3" json:"moves,omitempty"`
+	ElementalEffects map[string]float64     `protobuf:"bytes,16,rep,name=elemental_effects,json=elementalEffects,proto3" json:"elemental_effects,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	SpawnedAt        *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=spawned_at,json=spawnedAt,proto3" json:"spawned_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Pokemon) Reset() {
+	*x = Pokemon{}
+	mi := &file_pokemon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pokemon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pokemon) ProtoMessage() {}
+
+func (x *Pokemon) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pokemon.ProtoReflect.Descriptor instead.
+func (*Pokemon) Descriptor() ([]byte, []int) {
+	return file_pokemon_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Pokemon) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Pokemon) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Pokemon) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pokemon) GetForm() string {
+	if x != nil {
+		return x.Form
+	}
+	return ""
+}
+
+func (x *Pokemon) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Pokemon) GetType() []string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *Pokemon) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Pokemon) GetBaseExp() int32 {
+	if x != nil {
+		return x.BaseExp
+	}
+	return 0
+}
+
+func (x *Pokemon) GetGrowthRate() string {
+	if x != nil {
+		return x.GrowthRate
+	}
+	return ""
+}
+
+func (x *Pokemon) GetExp() int32 {
+	if x != nil {
+		return x.Exp
+	}
+	return 0
+}
+
+func (x *Pokemon) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Pokemon) GetEvYield() *Stats {
+	if x != nil {
+		return x.EvYield
+	}
+	return nil
+}
+
+func (x *Pokemon) GetAttributes() *Attributes {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Pokemon) GetIvs() *Stats {
+	if x != nil {
+		return x.Ivs
+	}
+	return nil
+}
+
+func (x *Pokemon) GetEvs() *Stats {
+	if x != nil {
+		return x.Evs
+	}
+	return nil
+}
+
+func (x *Pokemon) GetNature() string {
+	if x != nil {
+		return x.Nature
+	}
+	return ""
+}
+
+func (x *Pokemon) GetAbilities() []*Ability {
+	if x != nil {
+		return x.Abilities
+	}
+	return nil
+}
+
+func (x *Pokemon) GetAbility() string {
+	if x != nil {
+		return x.Ability
+	}
+	return ""
+}
+
+func (x *Pokemon) GetMoves() []*Move {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *Pokemon) GetElementalEffects() map[string]float64 {
+	if x != nil {
+		return x.ElementalEffects
+	}
+	return nil
+}
+
+func (x *Pokemon) GetSpawnedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SpawnedAt
+	}
+	return nil
+}
+
+type Pokedex struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemons      []*Pokemon             `protobuf:"bytes,1,rep,name=pokemons,proto3" json:"pokemons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pokedex) Reset() {
+	*x = Pokedex{}
+	mi := &file_pokemon_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pokedex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pokedex) ProtoMessage() {}
+
+func (x *Pokedex) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pokedex.ProtoReflect.Descriptor instead.
+func (*Pokedex) Descriptor() ([]byte, []int) {
+	return file_pokemon_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Pokedex) GetPokemons() []*Pokemon {
+	if x != nil {
+		return x.Pokemons
+	}
+	return nil
+}
+
+var File_pokemon_proto protoreflect.FileDescriptor
+
+const file_pokemon_proto_rawDesc = "" +
+	"\n" +
+	"\rpokemon.proto\x12\apokedbc\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9b\x01\n" +
+	"\x05Stats\x12\x0e\n" +
+	"\x02hp\x18\x01 \x01(\x05R\x02hp\x12\x16\n" +
+	"\x06attack\x18\x02 \x01(\x05R\x06attack\x12\x18\n" +
+	"\adefense\x18\x03 \x01(\x05R\adefense\x12\x14\n" +
+	"\x05speed\x18\x04 \x01(\x05R\x05speed\x12\x1b\n" +
+	"\tsp_attack\x18\x05 \x01(\x05R\bspAttack\x12\x1d\n" +
+	"\n" +
+	"sp_defense\x18\x06 \x01(\x05R\tspDefense\"\xc6\x01\n" +
+	"\n" +
+	"Attributes\x12\x0e\n" +
+	"\x02hp\x18\x01 \x01(\x05R\x02hp\x12\x16\n" +
+	"\x06attack\x18\x02 \x01(\x05R\x06attack\x12\x18\n" +
+	"\adefense\x18\x03 \x01(\x05R\adefense\x12\x14\n" +
+	"\x05speed\x18\x04 \x01(\x05R\x05speed\x12\x1b\n" +
+	"\tsp_attack\x18\x05 \x01(\x05R\bspAttack\x12\x1d\n" +
+	"\n" +
+	"sp_defense\x18\x06 \x01(\x05R\tspDefense\x12$\n" +
+	"\x0edmg_when_atked\x18\a \x01(\x05R\fdmgWhenAtked\"W\n" +
+	"\aAbility\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06hidden\x18\x03 \x01(\bR\x06hidden\"\xc0\x01\n" +
+	"\x04Move\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05power\x18\x04 \x01(\x05R\x05power\x12\x1a\n" +
+	"\baccuracy\x18\x05 \x01(\x05R\baccuracy\x12\x0e\n" +
+	"\x02pp\x18\x06 \x01(\x05R\x02pp\x12\x16\n" +
+	"\x06effect\x18\a \x01(\tR\x06effect\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\x05R\bpriority\"\xd6\x06\n" +
+	"\aPokemon\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x05R\x06number\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04form\x18\x04 \x01(\tR\x04form\x121\n" +
+	"\x05names\x18\x12 \x03(\v2\x1b.pokedbc.Pokemon.NamesEntryR\x05names\x12\x12\n" +
+	"\x04type\x18\x05 \x03(\tR\x04type\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x05R\x05total\x12\x19\n" +
+	"\bbase_exp\x18\a \x01(\x05R\abaseExp\x12\x1f\n" +
+	"\vgrowth_rate\x18\b \x01(\tR\n" +
+	"growthRate\x12\x10\n" +
+	"\x03exp\x18\t \x01(\x05R\x03exp\x12\x14\n" +
+	"\x05level\x18\n" +
+	" \x01(\x05R\x05level\x12)\n" +
+	"\bev_yield\x18\v \x01(\v2\x0e.pokedbc.StatsR\aevYield\x123\n" +
+	"\n" +
+	"attributes\x18\f \x01(\v2\x13.pokedbc.AttributesR\n" +
+	"attributes\x12 \n" +
+	"\x03ivs\x18\r \x01(\v2\x0e.pokedbc.StatsR\x03ivs\x12 \n" +
+	"\x03evs\x18\x0e \x01(\v2\x0e.pokedbc.StatsR\x03evs\x12\x16\n" +
+	"\x06nature\x18\x0f \x01(\tR\x06nature\x12.\n" +
+	"\tabilities\x18\x13 \x03(\v2\x10.pokedbc.AbilityR\tabilities\x12\x18\n" +
+	"\aability\x18\x14 \x01(\tR\aability\x12#\n" +
+	"\x05moves\x18\x15 \x03(\v2\r.pokedbc.MoveR\x05moves\x12S\n" +
+	"\x11elemental_effects\x18\x10 \x03(\v2&.pokedbc.Pokemon.ElementalEffectsEntryR\x10elementalEffects\x129\n" +
+	"\n" +
+	"spawned_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tspawnedAt\x1a8\n" +
+	"\n" +
+	"NamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aC\n" +
+	"\x15ElementalEffectsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"7\n" +
+	"\aPokedex\x12,\n" +
+	"\bpokemons\x18\x01 \x03(\v2\x10.pokedbc.PokemonR\bpokemonsB3Z1github.com/thanhduy1706/PokeDBC/pokemon/pokemonpbb\x06proto3"
+
+var (
+	file_pokemon_proto_rawDescOnce sync.Once
+	file_pokemon_proto_rawDescData []byte
+)
+
+func file_pokemon_proto_rawDescGZIP() []byte {
+	file_pokemon_proto_rawDescOnce.Do(func() {
+		file_pokemon_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pokemon_proto_rawDesc), len(file_pokemon_proto_rawDesc)))
+	})
+	return file_pokemon_proto_rawDescData
+}
+
+var file_pokemon_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_pokemon_proto_goTypes = []any{
+	(*Stats)(nil),                 // 0: pokedbc.Stats
+	(*Attributes)(nil),            // 1: pokedbc.Attributes
+	(*Ability)(nil),               // 2: pokedbc.Ability
+	(*Move)(nil),                  // 3: pokedbc.Move
+	(*Pokemon)(nil),               // 4: pokedbc.Pokemon
+	(*Pokedex)(nil),               // 5: pokedbc.Pokedex
+	nil,                           // 6: pokedbc.Pokemon.NamesEntry
+	nil,                           // 7: pokedbc.Pokemon.ElementalEffectsEntry
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_pokemon_proto_depIdxs = []int32{
+	6,  // 0: pokedbc.Pokemon.names:type_name -> pokedbc.Pokemon.NamesEntry
+	0,  // 1: pokedbc.Pokemon.ev_yield:type_name -> pokedbc.Stats
+	1,  // 2: pokedbc.Pokemon.attributes:type_name -> pokedbc.Attributes
+	0,  // 3: pokedbc.Pokemon.ivs:type_name -> pokedbc.Stats
+	0,  // 4: pokedbc.Pokemon.evs:type_name -> pokedbc.Stats
+	2,  // 5: pokedbc.Pokemon.abilities:type_name -> pokedbc.Ability
+	3,  // 6: pokedbc.Pokemon.moves:type_name -> pokedbc.Move
+	7,  // 7: pokedbc.Pokemon.elemental_effects:type_name -> pokedbc.Pokemon.ElementalEffectsEntry
+	8,  // 8: pokedbc.Pokemon.spawned_at:type_name -> google.protobuf.Timestamp
+	4,  // 9: pokedbc.Pokedex.pokemons:type_name -> pokedbc.Pokemon
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_pokemon_proto_init() }
+func file_pokemon_proto_init() {
+	if File_pokemon_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pokemon_proto_rawDesc), len(file_pokemon_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pokemon_proto_goTypes,
+		DependencyIndexes: file_pokemon_proto_depIdxs,
+		MessageInfos:      file_pokemon_proto_msgTypes,
+	}.Build()
+	File_pokemon_proto = out.File
+	file_pokemon_proto_goTypes = nil
+	file_pokemon_proto_depIdxs = nil
+}
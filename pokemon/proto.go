@@ -0,0 +1,167 @@
+package pokemon
+
+import (
+	"io"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon/pokemonpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProto converts p to its protobuf message.
+func ToProto(p Pokemon) *pokemonpb.Pokemon {
+	m := &pokemonpb.Pokemon{
+		Key:        p.Key,
+		Number:     int32(p.Number),
+		Name:       p.Name,
+		Form:       p.Form,
+		Names:      p.Names,
+		Type:       p.Type,
+		Total:      int32(p.Total),
+		BaseExp:    int32(p.BaseExp),
+		GrowthRate: p.GrowthRate,
+		Exp:        int32(p.Experience),
+		Level:      int32(p.Level),
+		EvYield:    statsToProto(p.EVYield),
+		Attributes: &pokemonpb.Attributes{
+			Hp:           int32(p.Attributes.HP),
+			Attack:       int32(p.Attributes.Attack),
+			Defense:      int32(p.Attributes.Defense),
+			Speed:        int32(p.Attributes.Speed),
+			SpAttack:     int32(p.Attributes.SpAttack),
+			SpDefense:    int32(p.Attributes.SpDefense),
+			DmgWhenAtked: int32(p.Attributes.DmgWhenAtked),
+		},
+		Ivs:              statsToProto(p.IVs),
+		Evs:              statsToProto(p.EVs),
+		Nature:           p.Nature,
+		Abilities:        make([]*pokemonpb.Ability, len(p.Abilities)),
+		Ability:          p.Ability,
+		Moves:            make([]*pokemonpb.Move, len(p.Moves)),
+		ElementalEffects: p.ElementalEffects,
+	}
+	for i, a := range p.Abilities {
+		m.Abilities[i] = &pokemonpb.Ability{Name: a.Name, Description: a.Description, Hidden: a.Hidden}
+	}
+	for i, mv := range p.Moves {
+		m.Moves[i] = &pokemonpb.Move{
+			Name:     mv.Name,
+			Type:     mv.Type,
+			Category: mv.Category,
+			Power:    int32(mv.Power),
+			Accuracy: int32(mv.Accuracy),
+			Pp:       int32(mv.PP),
+			Priority: int32(mv.Priority),
+			Effect:   mv.Effect,
+		}
+	}
+	if !p.SpawnedAt.IsZero() {
+		m.SpawnedAt = timestamppb.New(p.SpawnedAt)
+	}
+	return m
+}
+
+// FromProto converts a protobuf message back to a Pokemon.
+func FromProto(m *pokemonpb.Pokemon) Pokemon {
+	a := m.GetAttributes()
+	p := Pokemon{
+		Key:        m.GetKey(),
+		Number:     int(m.GetNumber()),
+		Name:       m.GetName(),
+		Form:       m.GetForm(),
+		Names:      m.GetNames(),
+		Type:       m.GetType(),
+		Total:      int(m.GetTotal()),
+		BaseExp:    int(m.GetBaseExp()),
+		GrowthRate: m.GetGrowthRate(),
+		Experience: int(m.GetExp()),
+		Level:      int(m.GetLevel()),
+		EVYield:    statsFromProto(m.GetEvYield()),
+		Attributes: Attributes{
+			HP:           int(a.GetHp()),
+			Attack:       int(a.GetAttack()),
+			Defense:      int(a.GetDefense()),
+			Speed:        int(a.GetSpeed()),
+			SpAttack:     int(a.GetSpAttack()),
+			SpDefense:    int(a.GetSpDefense()),
+			DmgWhenAtked: int(a.GetDmgWhenAtked()),
+		},
+		IVs:              statsFromProto(m.GetIvs()),
+		EVs:              statsFromProto(m.GetEvs()),
+		Nature:           m.GetNature(),
+		Ability:          m.GetAbility(),
+		ElementalEffects: m.GetElementalEffects(),
+	}
+	for _, a := range m.GetAbilities() {
+		p.Abilities = append(p.Abilities, Ability{Name: a.GetName(), Description: a.GetDescription(), Hidden: a.GetHidden()})
+	}
+	for _, mv := range m.GetMoves() {
+		p.Moves = append(p.Moves, Move{
+			Name:     mv.GetName(),
+			Type:     mv.GetType(),
+			Category: mv.GetCategory(),
+			Power:    int(mv.GetPower()),
+			Accuracy: int(mv.GetAccuracy()),
+			PP:       int(mv.GetPp()),
+			Priority: int(mv.GetPriority()),
+			Effect:   mv.GetEffect(),
+		})
+	}
+	if m.SpawnedAt != nil {
+		p.SpawnedAt = m.GetSpawnedAt().AsTime()
+	}
+	return p
+}
+
+func statsToProto(s Stats) *pokemonpb.Stats {
+	return &pokemonpb.Stats{
+		Hp:        int32(s.HP),
+		Attack:    int32(s.Attack),
+		Defense:   int32(s.Defense),
+		Speed:     int32(s.Speed),
+		SpAttack:  int32(s.SpAttack),
+		SpDefense: int32(s.SpDefense),
+	}
+}
+
+func statsFromProto(m *pokemonpb.Stats) Stats {
+	return Stats{
+		HP:        int(m.GetHp()),
+		Attack:    int(m.GetAttack()),
+		Defense:   int(m.GetDefense()),
+		Speed:     int(m.GetSpeed()),
+		SpAttack:  int(m.GetSpAttack()),
+		SpDefense: int(m.GetSpDefense()),
+	}
+}
+
+// WriteProto writes pokemons as one binary pokemonpb.Pokedex message.
+func WriteProto(w io.Writer, pokemons []Pokemon) error {
+	dex := &pokemonpb.Pokedex{Pokemons: make([]*pokemonpb.Pokemon, len(pokemons))}
+	for i, p := range pokemons {
+		dex.Pokemons[i] = ToProto(p)
+	}
+	data, err := proto.Marshal(dex)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// ReadProto reads what WriteProto writes.
+func ReadProto(r io.Reader) ([]Pokemon, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var dex pokemonpb.Pokedex
+	if err := proto.Unmarshal(data, &dex); err != nil {
+		return nil, err
+	}
+	pokemons := make([]Pokemon, len(dex.Pokemons))
+	for i, m := range dex.Pokemons {
+		pokemons[i] = FromProto(m)
+	}
+	return pokemons, nil
+}
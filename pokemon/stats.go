@@ -0,0 +1,159 @@
+package pokemon
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const (
+	MaxIV       = 31
+	MaxEV       = 252
+	MaxTotalEVs = 510
+)
+
+// natures maps each nature to the stat it raises and the stat it lowers
+// by 10%. The five neutral natures raise and lower the same stat.
+var natures = map[string][2]string{
+	"hardy":   {"attack", "attack"},
+	"lonely":  {"attack", "defense"},
+	"brave":   {"attack", "speed"},
+	"adamant": {"attack", "sp_attack"},
+	"naughty": {"attack", "sp_defense"},
+	"bold":    {"defense", "attack"},
+	"docile":  {"defense", "defense"},
+	"relaxed": {"defense", "speed"},
+	"impish":  {"defense", "sp_attack"},
+	"lax":     {"defense", "sp_defense"},
+	"timid":   {"speed", "attack"},
+	"hasty":   {"speed", "defense"},
+	"serious": {"speed", "speed"},
+	"jolly":   {"speed", "sp_attack"},
+	"naive":   {"speed", "sp_defense"},
+	"modest":  {"sp_attack", "attack"},
+	"mild":    {"sp_attack", "defense"},
+	"quiet":   {"sp_attack", "speed"},
+	"bashful": {"sp_attack", "sp_attack"},
+	"rash":    {"sp_attack", "sp_defense"},
+	"calm":    {"sp_defense", "attack"},
+	"gentle":  {"sp_defense", "defense"},
+	"sassy":   {"sp_defense", "speed"},
+	"careful": {"sp_defense", "sp_attack"},
+	"quirky":  {"sp_defense", "sp_defense"},
+}
+
+// NatureModifier is the multiplier nature applies to stat, named as the
+// Attributes json tags. Unknown natures are neutral.
+func NatureModifier(nature, stat string) float64 {
+	n, ok := natures[nature]
+	switch {
+	case !ok || n[0] == n[1]:
+		return 1
+	case n[0] == stat:
+		return 1.1
+	case n[1] == stat:
+		return 0.9
+	}
+	return 1
+}
+
+// CalcStats derives an owned Pokemon's stats at its Level from the
+// species' base stats in Attributes, its IVs, EVs and nature, using the
+// main series formula:
+//
+//	HP    = (2*base + IV + EV/4) * level/100 + level + 10
+//	other = ((2*base + IV + EV/4) * level/100 + 5) * nature
+//
+// DmgWhenAtked is carried over unchanged.
+func (p Pokemon) CalcStats() Attributes {
+	level := p.Level
+	if level < 1 {
+		level = 1
+	}
+	core := func(base, iv, ev int) int {
+		return (2*base + iv + ev/4) * level / 100
+	}
+	stat := func(name string, base, iv, ev int) int {
+		return int(float64(core(base, iv, ev)+5) * NatureModifier(p.Nature, name))
+	}
+
+	a := p.Attributes
+	return Attributes{
+		HP:           core(a.HP, p.IVs.HP, p.EVs.HP) + level + 10,
+		Attack:       stat("attack", a.Attack, p.IVs.Attack, p.EVs.Attack),
+		Defense:      stat("defense", a.Defense, p.IVs.Defense, p.EVs.Defense),
+		Speed:        stat("speed", a.Speed, p.IVs.Speed, p.EVs.Speed),
+		SpAttack:     stat("sp_attack", a.SpAttack, p.IVs.SpAttack, p.EVs.SpAttack),
+		SpDefense:    stat("sp_defense", a.SpDefense, p.IVs.SpDefense, p.EVs.SpDefense),
+		DmgWhenAtked: a.DmgWhenAtked,
+	}
+}
+
+// RollIndividual gives a newly captured Pokemon random IVs, a random
+// nature and one of its species' regular abilities, so two of the same
+// species and level end up with different stats. A nil r uses the
+// math/rand package functions.
+func (p *Pokemon) RollIndividual(r *rand.Rand) {
+	intn := rand.Intn
+	if r != nil {
+		intn = r.Intn
+	}
+	p.IVs = Stats{
+		HP:        intn(MaxIV + 1),
+		Attack:    intn(MaxIV + 1),
+		Defense:   intn(MaxIV + 1),
+		Speed:     intn(MaxIV + 1),
+		SpAttack:  intn(MaxIV + 1),
+		SpDefense: intn(MaxIV + 1),
+	}
+	names := make([]string, 0, len(natures))
+	for name := range natures {
+		names = append(names, name)
+	}
+	// Map iteration order is not uniformly random, so sort and pick by index
+	sort.Strings(names)
+	p.Nature = names[intn(len(names))]
+	p.rollAbility(intn)
+}
+
+// GainEffort adds the EV yield of a defeated Pokemon to p's EVs, capped at
+// MaxEV per stat and MaxTotalEVs overall.
+func (p *Pokemon) GainEffort(defeated Pokemon) {
+	evs := []*int{&p.EVs.HP, &p.EVs.Attack, &p.EVs.Defense, &p.EVs.Speed, &p.EVs.SpAttack, &p.EVs.SpDefense}
+	yield := []int{defeated.EVYield.HP, defeated.EVYield.Attack, defeated.EVYield.Defense,
+		defeated.EVYield.Speed, defeated.EVYield.SpAttack, defeated.EVYield.SpDefense}
+
+	total := 0
+	for _, ev := range evs {
+		total += *ev
+	}
+	for i, ev := range evs {
+		gain := yield[i]
+		if *ev+gain > MaxEV {
+			gain = MaxEV - *ev
+		}
+		if total+gain > MaxTotalEVs {
+			gain = MaxTotalEVs - total
+		}
+		if gain > 0 {
+			*ev += gain
+			total += gain
+		}
+	}
+}
+
+// Spawn makes a wild individual of species at level: experience at the
+// start of that level on the species' curve, rolled IVs, nature and
+// ability, no EVs yet, spawned now.
+func Spawn(species Pokemon, level int, r *rand.Rand) Pokemon {
+	p := species
+	if p.Key == "" {
+		p.Key = Key(p.Name, p.Form)
+	}
+	p.Level = level
+	p.Experience = ExpForLevel(p.GrowthRate, level)
+	p.EVs = Stats{}
+	p.RollIndividual(r)
+	p.SpawnedAt = time.Now()
+	return p
+}
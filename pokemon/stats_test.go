@@ -0,0 +1,150 @@
+package pokemon
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// garchomp is Bulbapedia's worked example of the stat formula.
+var garchomp = Pokemon{
+	Key: "garchomp", Name: "Garchomp", Level: 78, Nature: "adamant",
+	Attributes: Attributes{HP: 108, Attack: 130, Defense: 95, SpAttack: 80, SpDefense: 85, Speed: 102},
+	IVs:        Stats{HP: 24, Attack: 12, Defense: 30, SpAttack: 16, SpDefense: 23, Speed: 5},
+	EVs:        Stats{HP: 74, Attack: 190, Defense: 91, SpAttack: 48, SpDefense: 84, Speed: 23},
+}
+
+func TestCalcStats(t *testing.T) {
+	maxed := Stats{HP: MaxIV, Attack: MaxIV, Defense: MaxIV, Speed: MaxIV, SpAttack: MaxIV, SpDefense: MaxIV}
+	tests := []struct {
+		name string
+		p    Pokemon
+		want Attributes
+	}{
+		{"garchomp", garchomp, Attributes{HP: 289, Attack: 278, Defense: 193, SpAttack: 135, SpDefense: 171, Speed: 171}},
+		{
+			"blissey at level 100",
+			Pokemon{Level: 100, Nature: "bold", IVs: maxed, EVs: Stats{HP: MaxEV, Defense: MaxEV},
+				Attributes: Attributes{HP: 255, Attack: 10, Defense: 10, SpAttack: 75, SpDefense: 135, Speed: 55}},
+			Attributes{HP: 714, Attack: 50, Defense: 130, SpAttack: 186, SpDefense: 306, Speed: 146},
+		},
+		{
+			"neutral nature",
+			Pokemon{Level: 50, Nature: "hardy", IVs: maxed, EVs: Stats{Speed: MaxEV},
+				Attributes: Attributes{HP: 35, Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50, Speed: 90}},
+			Attributes{HP: 110, Attack: 75, Defense: 60, SpAttack: 70, SpDefense: 70, Speed: 142},
+		},
+		{
+			"no level, no nature",
+			Pokemon{Attributes: Attributes{HP: 45, Attack: 49, Defense: 49, SpAttack: 65, SpDefense: 65, Speed: 45, DmgWhenAtked: 3}},
+			Attributes{HP: 11, Attack: 5, Defense: 5, SpAttack: 6, SpDefense: 6, Speed: 5, DmgWhenAtked: 3},
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.p.CalcStats(); got != tt.want {
+			t.Errorf("%s:\n got %+v\nwant %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNatureModifier(t *testing.T) {
+	tests := []struct {
+		nature, stat string
+		want         float64
+	}{
+		{"adamant", "attack", 1.1},
+		{"adamant", "sp_attack", 0.9},
+		{"adamant", "speed", 1},
+		{"timid", "speed", 1.1},
+		{"timid", "attack", 0.9},
+		{"hardy", "attack", 1},
+		{"serious", "speed", 1},
+		{"", "attack", 1},
+		{"grumpy", "defense", 1},
+	}
+	for _, tt := range tests {
+		if got := NatureModifier(tt.nature, tt.stat); got != tt.want {
+			t.Errorf("NatureModifier(%q, %q) = %v, want %v", tt.nature, tt.stat, got, tt.want)
+		}
+	}
+
+	stats := []string{"attack", "defense", "speed", "sp_attack", "sp_defense"}
+	raised := make(map[[2]string]bool)
+	for nature, n := range natures {
+		if raised[n] {
+			t.Errorf("%s raises and lowers the same stats as another nature", nature)
+		}
+		raised[n] = true
+		for _, s := range stats {
+			want := 1.0
+			switch {
+			case n[0] == n[1]:
+			case s == n[0]:
+				want = 1.1
+			case s == n[1]:
+				want = 0.9
+			}
+			if got := NatureModifier(nature, s); got != want {
+				t.Errorf("NatureModifier(%q, %q) = %v, want %v", nature, s, got, want)
+			}
+		}
+	}
+	if len(natures) != 25 {
+		t.Errorf("%d natures, want 25", len(natures))
+	}
+}
+
+func TestGainEffort(t *testing.T) {
+	tests := []struct {
+		name  string
+		evs   Stats
+		yield Stats
+		want  Stats
+	}{
+		{"first battle", Stats{}, Stats{Attack: 2}, Stats{Attack: 2}},
+		{"two stats", Stats{HP: 4}, Stats{HP: 1, Speed: 1}, Stats{HP: 5, Speed: 1}},
+		{"stat cap", Stats{Attack: 251}, Stats{Attack: 3}, Stats{Attack: MaxEV}},
+		{"at the stat cap", Stats{Attack: MaxEV}, Stats{Attack: 1}, Stats{Attack: MaxEV}},
+		{"total cap", Stats{Attack: MaxEV, Speed: MaxEV, HP: 4}, Stats{Defense: 3}, Stats{Attack: MaxEV, Speed: MaxEV, HP: 4, Defense: 2}},
+		{"total cap in stat order", Stats{Attack: MaxEV, Speed: MaxEV, Defense: 5}, Stats{HP: 1, SpAttack: 2}, Stats{Attack: MaxEV, Speed: MaxEV, Defense: 5, HP: 1}},
+	}
+	for _, tt := range tests {
+		p := Pokemon{EVs: tt.evs}
+		p.GainEffort(Pokemon{EVYield: tt.yield})
+		if p.EVs != tt.want {
+			t.Errorf("%s: EVs = %+v, want %+v", tt.name, p.EVs, tt.want)
+		}
+	}
+}
+
+func TestRollIndividual(t *testing.T) {
+	species := Pokemon{
+		Key: "meowth", Name: "Meowth",
+		Abilities: []Ability{{Name: "Pickup"}, {Name: "Technician"}, {Name: "Unnerve", Hidden: true}},
+	}
+	for seed := int64(1); seed <= 50; seed++ {
+		a, b := species, species
+		a.RollIndividual(rand.New(rand.NewSource(seed)))
+		b.RollIndividual(rand.New(rand.NewSource(seed)))
+		if a.IVs != b.IVs || a.Nature != b.Nature || a.Ability != b.Ability {
+			t.Fatalf("seed %d rolled %+v %s %s, then %+v %s %s", seed, a.IVs, a.Nature, a.Ability, b.IVs, b.Nature, b.Ability)
+		}
+
+		for _, iv := range []int{a.IVs.HP, a.IVs.Attack, a.IVs.Defense, a.IVs.Speed, a.IVs.SpAttack, a.IVs.SpDefense} {
+			if iv < 0 || iv > MaxIV {
+				t.Errorf("seed %d: IV %d out of range", seed, iv)
+			}
+		}
+		if _, ok := natures[a.Nature]; !ok {
+			t.Errorf("seed %d: unknown nature %q", seed, a.Nature)
+		}
+		if a.Ability != "Pickup" && a.Ability != "Technician" {
+			t.Errorf("seed %d: ability %q, want a regular one", seed, a.Ability)
+		}
+	}
+
+	p := Pokemon{Name: "Missingno", Ability: "Stale"}
+	p.RollIndividual(rand.New(rand.NewSource(1)))
+	if p.Ability != "" {
+		t.Errorf("species without abilities rolled %q", p.Ability)
+	}
+}
@@ -0,0 +1,35 @@
+package pokemon
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WriteYAML writes pokemons as a YAML list, with the same field names as
+// pokedex.json.
+func WriteYAML(w io.Writer, pokemons []Pokemon) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if pokemons == nil {
+		pokemons = []Pokemon{}
+	}
+	if err := enc.Encode(pokemons); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// ReadYAML reads what WriteYAML writes.
+func ReadYAML(r io.Reader) ([]Pokemon, error) {
+	var pokemons []Pokemon
+	if err := yaml.NewDecoder(r).Decode(&pokemons); err != nil && err != io.EOF {
+		return nil, err
+	}
+	for i, p := range pokemons {
+		if p.Key == "" && p.Name != "" {
+			pokemons[i].Key = Key(p.Name, p.Form)
+		}
+	}
+	return pokemons, nil
+}
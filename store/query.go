@@ -0,0 +1,81 @@
+package store
+
+import (
+	"strings"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+// Range bounds a value inclusively. A zero bound is open, so Range{Min: 90}
+// means 90 or more.
+type Range struct {
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
+}
+
+func (r Range) Contains(v int) bool {
+	return v >= r.Min && (r.Max == 0 || v <= r.Max)
+}
+
+// Query selects entries. Zero fields match everything; an entry has to
+// match every field that is set.
+type Query struct {
+	Name    string           // the name, ignoring case; forms share it
+	Search  string           // part of the name or form, ignoring case
+	Locale  string           // Name and Search also match names in this locale, "" for any
+	Number  int              // national dex number
+	Types   []string         // has all of these types
+	Stats   map[string]Range // base stats by pokemon.StatNames
+	BaseExp Range
+	Limit   int // at most this many results
+}
+
+// Match reports whether p is selected by q. Limit is up to the caller.
+func (q Query) Match(p pokemon.Pokemon) bool {
+	if q.Name != "" && !p.HasName(q.Name, q.Locale) {
+		return false
+	}
+	if q.Search != "" && !q.searchMatches(p) {
+		return false
+	}
+	if q.Number > 0 && p.Number != q.Number {
+		return false
+	}
+	for _, t := range q.Types {
+		if !hasType(p, t) {
+			return false
+		}
+	}
+	for name, r := range q.Stats {
+		v, ok := p.Stat(name)
+		if !ok || !r.Contains(v) {
+			return false
+		}
+	}
+	return q.BaseExp.Contains(p.BaseExp)
+}
+
+func (q Query) searchMatches(p pokemon.Pokemon) bool {
+	search := strings.ToLower(q.Search)
+	names := []string{p.Name, p.Form}
+	if q.Locale != "" {
+		names = append(names, p.LocalName(q.Locale))
+	} else {
+		names = append(names, p.AllNames()...)
+	}
+	for _, name := range names {
+		if strings.Contains(strings.ToLower(name), search) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasType(p pokemon.Pokemon, t string) bool {
+	for _, pt := range p.Type {
+		if strings.EqualFold(pt, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+// Package store keeps the Pokedex in an embedded bbolt database so the
+// Pokedex command and the game servers can look entries up without
+// loading all of pokedex.json.
+//
+// Entries are stored as JSON under their key in the "pokemon" bucket.
+// The "number", "type" and "name" buckets index them; their keys end in
+// the entry's position in dex order, so scanning an index returns entries
+// in the order pokedex.json has them.
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	pokemonBucket = []byte("pokemon")
+	numberBucket  = []byte("number")
+	typeBucket    = []byte("type")
+	nameBucket    = []byte("name")
+)
+
+// ErrNotFound is returned by Get for keys that aren't in the store.
+var ErrNotFound = errors.New("pokemon not found")
+
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the database at path for writing, creating it if needed.
+// While it is open no other process can open it, not even to read; Open
+// gives up after a second.
+func Open(path string) (*Store, error) {
+	return open(path, &bolt.Options{Timeout: time.Second})
+}
+
+// OpenReadOnly opens an existing database for lookups only. Any number of
+// processes can read it at once, e.g. Pokedex serve and the world
+// servers, but not while one has it open with Open.
+func OpenReadOnly(path string) (*Store, error) {
+	return open(path, &bolt.Options{Timeout: time.Second, ReadOnly: true})
+}
+
+func open(path string, options *bolt.Options) (*Store, error) {
+	db, err := bolt.Open(path, 0o644, options)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Import replaces the contents of the store with pokemons, in dex order.
+// Every entry needs a key of its own; if any is missing or repeated, the
+// store is left as it was.
+func (s *Store) Import(pokemons []pokemon.Pokemon) error {
+	sorted := append([]pokemon.Pokemon(nil), pokemons...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Number < sorted[j].Number
+	})
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		buckets := make(map[string]*bolt.Bucket)
+		for _, name := range [][]byte{pokemonBucket, numberBucket, typeBucket, nameBucket} {
+			if err := tx.DeleteBucket(name); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+			b, err := tx.CreateBucket(name)
+			if err != nil {
+				return err
+			}
+			buckets[string(name)] = b
+		}
+
+		seen := make(map[string]bool, len(sorted))
+		for i, p := range sorted {
+			if p.Key == "" {
+				return fmt.Errorf("entry %d (%s) has no key", i, p.Name)
+			}
+			if seen[p.Key] {
+				return fmt.Errorf("entry %d (%s) repeats key %s", i, p.Name, p.Key)
+			}
+			seen[p.Key] = true
+			data, err := json.Marshal(p)
+			if err != nil {
+				return fmt.Errorf("encoding %s: %w", p.Key, err)
+			}
+			if err := buckets[string(pokemonBucket)].Put([]byte(p.Key), data); err != nil {
+				return err
+			}
+
+			pos := uint32(i)
+			if err := buckets[string(numberBucket)].Put(indexKey(numberPrefix(p.Number), pos), []byte(p.Key)); err != nil {
+				return err
+			}
+			for _, t := range p.Type {
+				if err := buckets[string(typeBucket)].Put(indexKey(textPrefix(t), pos), []byte(p.Key)); err != nil {
+					return err
+				}
+			}
+			for _, name := range p.AllNames() {
+				if err := buckets[string(nameBucket)].Put(indexKey(textPrefix(name), pos), []byte(p.Key)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// ImportFile loads a file in any shape pokemon.Load reads and imports it,
+// returning the number of entries.
+func (s *Store) ImportFile(name string) (int, error) {
+	pokemons, err := pokemon.Load(name)
+	if err != nil {
+		return 0, err
+	}
+	return len(pokemons), s.Import(pokemons)
+}
+
+// Get returns the entry with the given key, e.g. "meowth-alolan".
+func (s *Store) Get(key string) (pokemon.Pokemon, error) {
+	var p pokemon.Pokemon
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(pokemonBucket)
+		if b == nil {
+			return ErrNotFound
+		}
+		data := b.Get([]byte(key))
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &p)
+	})
+	return p, err
+}
+
+// All returns every entry in dex order.
+func (s *Store) All() ([]pokemon.Pokemon, error) {
+	return s.Find(Query{})
+}
+
+// Find returns the entries matching q in dex order. The number, type or
+// name index narrows the scan when q sets one of them; the rest of q is
+// checked on each candidate.
+func (s *Store) Find(q Query) ([]pokemon.Pokemon, error) {
+	var index []byte
+	var prefix []byte
+	switch {
+	case q.Number > 0:
+		index, prefix = numberBucket, numberPrefix(q.Number)
+	case len(q.Types) > 0:
+		index, prefix = typeBucket, textPrefix(q.Types[0])
+	case q.Name != "":
+		index, prefix = nameBucket, textPrefix(q.Name)
+	default:
+		index = numberBucket
+	}
+
+	var found []pokemon.Pokemon
+	err := s.db.View(func(tx *bolt.Tx) error {
+		entries, idx := tx.Bucket(pokemonBucket), tx.Bucket(index)
+		if entries == nil || idx == nil {
+			return nil
+		}
+
+		c := idx.Cursor()
+		for k, key := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), string(prefix)); k, key = c.Next() {
+			var p pokemon.Pokemon
+			if err := json.Unmarshal(entries.Get(key), &p); err != nil {
+				return fmt.Errorf("decoding %s: %w", key, err)
+			}
+			if !q.Match(p) {
+				continue
+			}
+			found = append(found, p)
+			if q.Limit > 0 && len(found) == q.Limit {
+				break
+			}
+		}
+		return nil
+	})
+	return found, err
+}
+
+// numberPrefix sorts dex numbers numerically.
+func numberPrefix(n int) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(n))
+}
+
+// textPrefix matches names and types regardless of case. The separator
+// keeps "Mew" from matching "Mewtwo".
+func textPrefix(s string) []byte {
+	return append([]byte(strings.ToLower(s)), 0)
+}
+
+func indexKey(prefix []byte, pos uint32) []byte {
+	return binary.BigEndian.AppendUint32(append([]byte(nil), prefix...), pos)
+}
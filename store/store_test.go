@@ -0,0 +1,125 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/thanhduy1706/PokeDBC/pokemon"
+)
+
+var testPokedex = []pokemon.Pokemon{
+	{Key: "raichu", Number: 26, Name: "Raichu", Type: []string{"Electric"}, BaseExp: 243},
+	{Key: "pikachu", Number: 25, Name: "Pikachu", Type: []string{"Electric"}, BaseExp: 112, Names: pokemon.Names{"ja": "ピカチュウ"}},
+	{Key: "raichu-alolan", Number: 26, Name: "Raichu", Form: "Alolan Raichu", Type: []string{"Electric", "Psychic"}, BaseExp: 243},
+	{Key: "meowth", Number: 52, Name: "Meowth", Type: []string{"Normal"}, BaseExp: 58},
+}
+
+func openTest(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "pokedex.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	if err := s.Import(testPokedex); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	return s
+}
+
+func keys(pokemons []pokemon.Pokemon) []string {
+	keys := make([]string, len(pokemons))
+	for i, p := range pokemons {
+		keys[i] = p.Key
+	}
+	return keys
+}
+
+func TestFind(t *testing.T) {
+	s := openTest(t)
+	tests := []struct {
+		name string
+		q    Query
+		want []string
+	}{
+		{"all", Query{}, []string{"pikachu", "raichu", "raichu-alolan", "meowth"}},
+		{"name", Query{Name: "raichu"}, []string{"raichu", "raichu-alolan"}},
+		{"name in locale", Query{Name: "ピカチュウ", Locale: "ja"}, []string{"pikachu"}},
+		{"number", Query{Number: 26}, []string{"raichu", "raichu-alolan"}},
+		{"types", Query{Types: []string{"electric", "psychic"}}, []string{"raichu-alolan"}},
+		{"base exp", Query{BaseExp: Range{Max: 120}}, []string{"pikachu", "meowth"}},
+		{"limit", Query{Types: []string{"Electric"}, Limit: 2}, []string{"pikachu", "raichu"}},
+		{"none", Query{Name: "mew"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := s.Find(tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := keys(found); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	s := openTest(t)
+	p, err := s.Get("raichu-alolan")
+	if err != nil || p.Form != "Alolan Raichu" {
+		t.Errorf("Get(raichu-alolan) = %+v, %v", p, err)
+	}
+	if _, err := s.Get("mew"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(mew) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestImportRejectsRepeatedKeys(t *testing.T) {
+	s := openTest(t)
+	repeated := append(append([]pokemon.Pokemon(nil), testPokedex...), testPokedex[0])
+	if err := s.Import(repeated); err == nil {
+		t.Fatal("Import accepted a repeated key")
+	}
+	all, err := s.All()
+	if err != nil || len(all) != len(testPokedex) {
+		t.Errorf("after the failed import the store has %d entries (%v), want %d", len(all), err, len(testPokedex))
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Import(testPokedex); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	s.Close()
+
+	// Two readers at once, as Pokedex serve and a world server
+	readers := make([]*Store, 2)
+	for i := range readers {
+		r, err := OpenReadOnly(path)
+		if err != nil {
+			t.Fatalf("reader %d: %v", i+1, err)
+		}
+		defer r.Close()
+		readers[i] = r
+	}
+	for i, r := range readers {
+		if p, err := r.Get("pikachu"); err != nil || p.Name != "Pikachu" {
+			t.Errorf("reader %d: Get(pikachu) = %+v, %v", i+1, p, err)
+		}
+	}
+	if err := readers[0].Import(testPokedex[:1]); err == nil {
+		t.Error("Import into a read-only store succeeded")
+	}
+
+	if _, err := OpenReadOnly(filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Error("OpenReadOnly created a missing database")
+	}
+}
@@ -0,0 +1,134 @@
+// Package typechart looks up how effective an attack of one type is against
+// a Pokémon of one or two types.
+package typechart
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Chart is the type-effectiveness matrix. Matrix[i][j] is the multiplier
+// for an attack of type Types[i] hitting a Pokémon of type Types[j].
+type Chart struct {
+	Types  []string    `json:"types"`
+	Matrix [][]float64 `json:"matrix"`
+
+	index map[string]int // lower-cased type name -> row/column
+}
+
+//go:embed types.json
+var defaultJSON []byte
+
+var (
+	defaultOnce  sync.Once
+	defaultChart *Chart
+)
+
+// Default returns the chart bundled with the package, as scraped by the
+// Pokedex command into types.json.
+func Default() *Chart {
+	defaultOnce.Do(func() {
+		c, err := Parse(defaultJSON)
+		if err != nil {
+			panic("typechart: bundled types.json: " + err.Error())
+		}
+		defaultChart = c
+	})
+	return defaultChart
+}
+
+// Effectiveness looks up a multiplier in the default chart.
+func Effectiveness(attackType string, defenderTypes ...string) float64 {
+	return Default().Effectiveness(attackType, defenderTypes...)
+}
+
+// Load reads a chart from a types.json file.
+func Load(name string) (*Chart, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes a chart from JSON and checks that the matrix is square.
+func Parse(data []byte) (*Chart, error) {
+	var c Chart
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (c *Chart) validate() error {
+	if len(c.Matrix) != len(c.Types) {
+		return fmt.Errorf("typechart: %d types but %d rows", len(c.Types), len(c.Matrix))
+	}
+	for i, row := range c.Matrix {
+		if len(row) != len(c.Types) {
+			return fmt.Errorf("typechart: row %s has %d columns, want %d", c.Types[i], len(row), len(c.Types))
+		}
+	}
+
+	c.index = make(map[string]int, len(c.Types))
+	for i, t := range c.Types {
+		c.index[strings.ToLower(t)] = i
+	}
+	return nil
+}
+
+// Effectiveness multiplies the matchups of attackType against each of the
+// defender's types, e.g. Ground vs Fire/Flying = 2 * 0 = 0. Type names are
+// case-insensitive; unknown types count as neutral.
+func (c *Chart) Effectiveness(attackType string, defenderTypes ...string) float64 {
+	row, ok := c.index[strings.ToLower(attackType)]
+	if !ok {
+		return 1
+	}
+
+	multiplier := 1.0
+	for _, t := range defenderTypes {
+		if col, ok := c.index[strings.ToLower(t)]; ok {
+			multiplier *= c.Matrix[row][col]
+		}
+	}
+	return multiplier
+}
+
+// Has reports whether the chart knows the type.
+func (c *Chart) Has(t string) bool {
+	_, ok := c.index[strings.ToLower(t)]
+	return ok
+}
+
+// Build makes a chart from rows of multipliers keyed by attacking type
+// then defending type, as read from a table. It fails unless every type
+// has a full row.
+func Build(types []string, rows map[string]map[string]float64) (*Chart, error) {
+	c := &Chart{Types: types, Matrix: make([][]float64, len(types))}
+	for i, attack := range types {
+		row, ok := rows[attack]
+		if !ok {
+			return nil, fmt.Errorf("typechart: no row for %s", attack)
+		}
+		c.Matrix[i] = make([]float64, len(types))
+		for j, defend := range types {
+			m, ok := row[defend]
+			if !ok {
+				return nil, fmt.Errorf("typechart: no %s -> %s entry", attack, defend)
+			}
+			c.Matrix[i][j] = m
+		}
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,93 @@
+package typechart
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEffectiveness(t *testing.T) {
+	tests := []struct {
+		attack  string
+		defends []string
+		want    float64
+	}{
+		{"Fire", []string{"Grass"}, 2},
+		{"Water", []string{"Fire"}, 2},
+		{"Fire", []string{"Water"}, 0.5},
+		{"Normal", []string{"Ghost"}, 0},
+		{"Ghost", []string{"Normal"}, 0},
+		{"Electric", []string{"Ground"}, 0},
+		{"Dragon", []string{"Fairy"}, 0},
+		{"Ground", []string{"Fire", "Flying"}, 0},
+		{"Ice", []string{"Dragon", "Flying"}, 4},
+		{"Fire", []string{"Bug", "Steel"}, 4},
+		{"Fighting", []string{"Flying", "Poison"}, 0.25},
+		{"Water", []string{"Water", "Ground"}, 1},
+		{"electric", []string{"WATER"}, 2},
+		{"Sound", []string{"Water"}, 1},
+		{"Fire", []string{"Shadow", "Grass"}, 2},
+		{"Fire", nil, 1},
+	}
+	for _, tt := range tests {
+		if got := Effectiveness(tt.attack, tt.defends...); got != tt.want {
+			t.Errorf("Effectiveness(%s, %v) = %v, want %v", tt.attack, tt.defends, got, tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "types.json")
+	data := `{"types": ["Fire", "Water"], "matrix": [[0.5, 0.5], [2, 0.5]]}`
+	if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(name)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := c.Effectiveness("water", "fire"); got != 2 {
+		t.Errorf("water vs fire = %v, want 2", got)
+	}
+	if !c.Has("FIRE") || c.Has("Grass") {
+		t.Errorf("Has: fire %v, grass %v; want true, false", c.Has("FIRE"), c.Has("Grass"))
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load of a missing file succeeded")
+	}
+}
+
+func TestParseRejectsBadMatrix(t *testing.T) {
+	for _, data := range []string{
+		`{"types": ["Fire", "Water"], "matrix": [[1, 1]]}`,
+		`{"types": ["Fire", "Water"], "matrix": [[1, 1], [1]]}`,
+		`{"types": ["Fire"], "matrix": `,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%s) succeeded", data)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	rows := map[string]map[string]float64{
+		"Fire":  {"Fire": 0.5, "Water": 0.5},
+		"Water": {"Fire": 2, "Water": 0.5},
+	}
+	c, err := Build([]string{"Fire", "Water"}, rows)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if got := c.Effectiveness("Water", "Fire"); got != 2 {
+		t.Errorf("water vs fire = %v, want 2", got)
+	}
+
+	delete(rows["Water"], "Fire")
+	if _, err := Build([]string{"Fire", "Water"}, rows); err == nil {
+		t.Error("Build with a missing entry succeeded")
+	}
+	if _, err := Build([]string{"Fire", "Grass"}, rows); err == nil {
+		t.Error("Build with a missing row succeeded")
+	}
+}